@@ -6,70 +6,34 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"os"
 
 	"github.com/reviewpad/reviewpad/v3"
-	"github.com/reviewpad/reviewpad/v3/lang/aladino"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	rootCmd.AddCommand(checkCmd)
+	addFileFlag(checkCmd)
+	checkCmd.Flags().StringVarP(&gitHubToken, "github-token", "t", "", "GitHub personal access token to import files from private repositories")
 }
 
 var checkCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check if input reviewpad file is valid",
+	Long:  "Check if input reviewpad file is valid, i.e. its specs and actions are well typed for the built-ins",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		data, err := os.ReadFile(reviewpadFile)
 		if err != nil {
 			return err
 		}
 
-		buf := bytes.NewBuffer(data)
-		reviewpadFile, err := reviewpad.Load(buf)
-		if err != nil {
-			return err
-		}
-
-		for _, group := range reviewpadFile.Groups {
-			if group.Spec != "" {
-				if _, err = aladino.Parse(group.Spec); err != nil {
-					return err
-				}
-			}
-
-			if group.Where != "" {
-				if _, err = aladino.Parse(group.Where); err != nil {
-					return err
-				}
-			}
-		}
-
-		for _, rules := range reviewpadFile.Rules {
-			if rules.Spec != "" {
-				if _, err = aladino.Parse(rules.Spec); err != nil {
-					return err
-				}
-			}
-		}
+		ctx := context.Background()
 
-		for _, workflow := range reviewpadFile.Workflows {
-			for _, rule := range workflow.Rules {
-				for _, action := range rule.ExtraActions {
-					if _, err = aladino.Parse(action); err != nil {
-						return err
-					}
-				}
-			}
-
-			for _, action := range workflow.Actions {
-				if _, err = aladino.Parse(action); err != nil {
-					return err
-				}
-			}
-		}
+		// Loading the file lints it, which type checks its specs and actions.
+		_, err = reviewpad.LoadFile(ctx, importsGithubClient(ctx), reviewpadFile, bytes.NewBuffer(data))
 
-		return nil
+		return err
 	},
 }
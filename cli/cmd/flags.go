@@ -5,8 +5,10 @@
 package cmd
 
 var (
+	allowActions  bool
 	dryRun        bool
 	eventFilePath string
+	fmtCheck      bool
 	gitHubToken   string
 	mixpanelToken string
 	githubUrl     string
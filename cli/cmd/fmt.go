@@ -0,0 +1,51 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/reviewpad/reviewpad/v3"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(fmtCmd)
+	addFileFlag(fmtCmd)
+	fmtCmd.Flags().BoolVarP(&fmtCheck, "check", "c", false, "Check if the input reviewpad file is formatted without rewriting it")
+}
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt",
+	Short: "Rewrite the specs of the input reviewpad file in canonical form",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(reviewpadFile)
+		if err != nil {
+			return err
+		}
+
+		formatted, err := reviewpad.Format(data)
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(data, formatted) {
+			return nil
+		}
+
+		if fmtCheck {
+			return fmt.Errorf("%v is not formatted", reviewpadFile)
+		}
+
+		info, err := os.Stat(reviewpadFile)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(reviewpadFile, formatted, info.Mode())
+	},
+}
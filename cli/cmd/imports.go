@@ -0,0 +1,66 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/go-github/v45/github"
+	gh "github.com/reviewpad/reviewpad/v3/codehost/github"
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(importsCmd)
+	importsCmd.AddCommand(importsLockCmd)
+	addFileFlag(importsLockCmd)
+	importsLockCmd.Flags().StringVarP(&gitHubToken, "github-token", "t", "", "GitHub personal access token to import files from private repositories")
+}
+
+// importsGithubClient is the GitHub client that fetches the files imported from repositories.
+// Without a token, the files are imported from public repositories only.
+func importsGithubClient(ctx context.Context) *gh.GithubClient {
+	if gitHubToken == "" {
+		return gh.NewGithubClient(github.NewClient(nil), nil)
+	}
+
+	return gh.NewGithubClientFromToken(ctx, gitHubToken)
+}
+
+var importsCmd = &cobra.Command{
+	Use:   "imports",
+	Short: "Manage the imports of the input reviewpad file",
+}
+
+var importsLockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Pin the sha256 of the remote imports of the input reviewpad file in its lockfile",
+	Long: "Pin the sha256 of the remote imports of the input reviewpad file in its lockfile, e.g. reviewpad.lock.yml for reviewpad.yml. " +
+		"Loading the input reviewpad file fails when the content of a pinned import changes, and uses the cached content of the pinned imports when they cannot be fetched.",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(reviewpadFile)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+
+		lock, err := engine.LockImports(ctx, importsGithubClient(ctx), reviewpadFile, data)
+		if err != nil {
+			return err
+		}
+
+		if err := engine.WriteLock(reviewpadFile, lock); err != nil {
+			return err
+		}
+
+		fmt.Printf("pinned %v imports in %v\n", len(lock.Imports), engine.LockFilePath(reviewpadFile))
+
+		return nil
+	},
+}
@@ -0,0 +1,35 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/google/go-github/v45/github"
+	gh "github.com/reviewpad/reviewpad/v3/codehost/github"
+	"github.com/reviewpad/reviewpad/v3/lsp"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(lspCmd)
+}
+
+var lspCmd = &cobra.Command{
+	Use:   "lsp",
+	Short: "Run the language server for reviewpad files",
+	Long:  "Run the language server for reviewpad files over the standard input and output, with diagnostics, completion of the built-ins and go to definition of rules and groups",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// The types of the built-ins do not depend on the services of the plugins.
+		builtIns := plugins_aladino.PluginBuiltInsWithConfig(&plugins_aladino.PluginConfig{})
+		// The files are imported from public repositories only.
+		githubClient := gh.NewGithubClient(github.NewClient(nil), nil)
+
+		server := lsp.NewServer(builtIns, githubClient)
+
+		return server.Serve(os.Stdin, os.Stdout)
+	},
+}
@@ -0,0 +1,72 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/reviewpad/reviewpad/v3"
+	gh "github.com/reviewpad/reviewpad/v3/codehost/github"
+	"github.com/reviewpad/reviewpad/v3/collector"
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(replCmd)
+	// Without the input reviewpad file, only the built-ins are available.
+	replCmd.Flags().StringVarP(&reviewpadFile, "file", "f", "", "input reviewpad file")
+	replCmd.Flags().BoolVarP(&allowActions, "allow-actions", "a", false, "Execute the actions typed in the REPL")
+	replCmd.Flags().StringVarP(&githubUrl, "github-url", "u", "", "GitHub pull request or issue url")
+	replCmd.Flags().StringVarP(&gitHubToken, "github-token", "t", "", "GitHub personal access token")
+	replCmd.Flags().StringVarP(&eventFilePath, "event-payload", "e", "", "File path to github action event in JSON format")
+
+	replCmd.MarkFlagRequired("github-url")
+	replCmd.MarkFlagRequired("github-token")
+}
+
+func repl() error {
+	ev, err := readEvent()
+	if err != nil {
+		return err
+	}
+
+	targetEntity, err := toTargetEntity(githubUrl)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	githubClient := gh.NewGithubClientFromToken(ctx, gitHubToken)
+	// Without a token, nothing is collected.
+	collectorClient := collector.NewCollector("", targetEntity.Owner, string(targetEntity.Kind), githubUrl)
+
+	var file *engine.ReviewpadFile
+	if reviewpadFile != "" {
+		data, err := os.ReadFile(reviewpadFile)
+		if err != nil {
+			return fmt.Errorf("error reading reviewpad file. Details: %v", err.Error())
+		}
+
+		file, err = reviewpad.LoadFile(ctx, githubClient, reviewpadFile, bytes.NewBuffer(data))
+		if err != nil {
+			return err
+		}
+	}
+
+	return reviewpad.Repl(ctx, githubClient, collectorClient, targetEntity, ev, file, allowActions, os.Stdin, os.Stdout)
+}
+
+var replCmd = &cobra.Command{
+	Use:   "repl",
+	Short: "Evaluate Aladino expressions on a pull request or issue",
+	Long:  "Evaluate Aladino expressions on a pull request or issue with the built-ins and the functions, groups and rules of the input reviewpad file, if any, printing their values and types",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return repl()
+	},
+}
@@ -0,0 +1,49 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(resolveCmd)
+	addFileFlag(resolveCmd)
+	resolveCmd.Flags().StringVarP(&gitHubToken, "github-token", "t", "", "GitHub personal access token to import files from private repositories")
+}
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Print the input reviewpad file with its imports resolved",
+	Long:  "Print the input reviewpad file with the definitions of its imports merged in, i.e. the file that reviewpad runs",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(reviewpadFile)
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+
+		// The file is not linted so that the resolved file can be inspected when it is invalid.
+		file, err := engine.LoadFile(ctx, importsGithubClient(ctx), reviewpadFile, data)
+		if err != nil {
+			return err
+		}
+
+		resolved, err := engine.Marshal(file)
+		if err != nil {
+			return err
+		}
+
+		fmt.Print(string(resolved))
+
+		return nil
+	},
+}
@@ -16,11 +16,15 @@ var rootCmd = &cobra.Command{
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringVarP(&reviewpadFile, "file", "f", "", "input reviewpad file")
-	rootCmd.MarkPersistentFlagRequired("file")
 	rootCmd.SilenceUsage = true
 }
 
+// addFileFlag adds the required input reviewpad file to a command that reads it.
+func addFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&reviewpadFile, "file", "f", "", "input reviewpad file")
+	cmd.MarkFlagRequired("file")
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -25,6 +25,7 @@ import (
 
 func init() {
 	rootCmd.AddCommand(runCmd)
+	addFileFlag(runCmd)
 	runCmd.Flags().BoolVarP(&dryRun, "dry-run", "d", false, "Dry run mode")
 	runCmd.Flags().BoolVarP(&safeModeRun, "safe-mode-run", "s", false, "Safe mode")
 	runCmd.Flags().StringVarP(&githubUrl, "github-url", "u", "", "GitHub pull request or issue url")
@@ -63,42 +64,61 @@ func toTargetEntityKind(entityType string) (handler.TargetEntityKind, error) {
 	}
 }
 
-func run() error {
-	var ev interface{}
-
+// readEvent reads the github action event of the run, if any.
+func readEvent() (interface{}, error) {
 	if eventFilePath == "" {
 		log.Print("[WARN] No event payload provided. Assuming empty event.")
-	} else {
-		content, err := ioutil.ReadFile(eventFilePath)
-		if err != nil {
-			return err
-		}
-
-		rawEvent := string(content)
-		ev, err = parseEvent(rawEvent)
-		if err != nil {
-			return err
-		}
+		return nil, nil
+	}
+
+	content, err := ioutil.ReadFile(eventFilePath)
+	if err != nil {
+		return nil, err
 	}
 
+	return parseEvent(string(content))
+}
+
+// toTargetEntity converts a GitHub pull request or issue url, e.g. https://github.com/reviewpad/reviewpad/pull/1, to its target entity.
+func toTargetEntity(url string) (*handler.TargetEntity, error) {
 	githubDetailsRegex := regexp.MustCompile(`github\.com\/(.+)\/(.+)\/(\w+)\/(\d+)`)
-	githubEntityDetails := githubDetailsRegex.FindSubmatch([]byte(githubUrl))
+	githubEntityDetails := githubDetailsRegex.FindSubmatch([]byte(url))
+	if githubEntityDetails == nil {
+		return nil, fmt.Errorf("invalid GitHub url %v", url)
+	}
 
-	repositoryOwner := string(githubEntityDetails[1][:])
-	repositoryName := string(githubEntityDetails[2][:])
 	entityKind, err := toTargetEntityKind(string(githubEntityDetails[3][:]))
 	if err != nil {
-		log.Fatalf("Error converting entity kind. Details %+q", err.Error())
+		return nil, fmt.Errorf("error converting entity kind. Details %+q", err.Error())
 	}
 
 	entityNumber, err := strconv.Atoi(string(githubEntityDetails[4][:]))
 	if err != nil {
-		log.Fatalf("Error converting entity number. Details %+q", err.Error())
+		return nil, fmt.Errorf("error converting entity number. Details %+q", err.Error())
+	}
+
+	return &handler.TargetEntity{
+		Owner:  string(githubEntityDetails[1][:]),
+		Repo:   string(githubEntityDetails[2][:]),
+		Number: entityNumber,
+		Kind:   entityKind,
+	}, nil
+}
+
+func run() error {
+	ev, err := readEvent()
+	if err != nil {
+		return err
+	}
+
+	targetEntity, err := toTargetEntity(githubUrl)
+	if err != nil {
+		return err
 	}
 
 	ctx := context.Background()
 	githubClient := gh.NewGithubClientFromToken(ctx, gitHubToken)
-	collectorClient := collector.NewCollector(mixpanelToken, repositoryOwner, string(entityKind), githubUrl)
+	collectorClient := collector.NewCollector(mixpanelToken, targetEntity.Owner, string(targetEntity.Kind), githubUrl)
 
 	data, err := os.ReadFile(reviewpadFile)
 	if err != nil {
@@ -106,18 +126,11 @@ func run() error {
 	}
 
 	buf := bytes.NewBuffer(data)
-	file, err := reviewpad.Load(buf)
+	file, err := reviewpad.LoadFile(ctx, githubClient, reviewpadFile, buf)
 	if err != nil {
 		return fmt.Errorf("error running reviewpad team edition. Details %v", err.Error())
 	}
 
-	targetEntity := &handler.TargetEntity{
-		Owner:  repositoryOwner,
-		Repo:   repositoryName,
-		Number: entityNumber,
-		Kind:   entityKind,
-	}
-
 	_, err = reviewpad.Run(ctx, githubClient, collectorClient, targetEntity, ev, file, dryRun, safeModeRun)
 	if err != nil {
 		return fmt.Errorf("error running reviewpad team edition. Details %v", err.Error())
@@ -0,0 +1,32 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(schemaCmd)
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON schema of reviewpad files",
+	Long:  fmt.Sprintf("Print the JSON schema of reviewpad files with api-version %v, e.g. for editors to validate them", engine.API_VERSION),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schema, err := json.MarshalIndent(engine.Schema(), "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), string(schema))
+		return nil
+	},
+}
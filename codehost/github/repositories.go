@@ -6,6 +6,7 @@ package github
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 
 	"github.com/google/go-github/v45/github"
@@ -39,3 +40,24 @@ func (c *GithubClient) DownloadContents(ctx context.Context, filePath string, br
 
 	return ioutil.ReadAll(ioReader)
 }
+
+// GetFileContents gets the contents of a file of a repository at a branch, tag or commit, or at the default branch when ref is empty.
+func (c *GithubClient) GetFileContents(ctx context.Context, owner string, repo string, filePath string, ref string) ([]byte, error) {
+	fileContent, _, _, err := c.clientREST.Repositories.GetContents(ctx, owner, repo, filePath, &github.RepositoryContentGetOptions{
+		Ref: ref,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if fileContent == nil {
+		return nil, fmt.Errorf("%v is not a file", filePath)
+	}
+
+	content, err := fileContent.GetContent()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(content), nil
+}
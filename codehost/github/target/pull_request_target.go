@@ -186,13 +186,20 @@ func (t *PullRequestTarget) GetReviews() ([]*codehost.Review, error) {
 	reviews := make([]*codehost.Review, len(ghPrReviews))
 
 	for i, ghPrReview := range ghPrReviews {
+		// The user of a review is nil when its account was deleted.
+		var user *codehost.User
+		if ghPrReview.User != nil {
+			user = &codehost.User{
+				Login: ghPrReview.User.GetLogin(),
+			}
+		}
+
 		reviews[i] = &codehost.Review{
-			ID:    *ghPrReview.ID,
-			Body:  *ghPrReview.Body,
-			State: *ghPrReview.State,
-			User: &codehost.User{
-				Login: *ghPrReview.User.Login,
-			},
+			ID:          *ghPrReview.ID,
+			Body:        *ghPrReview.Body,
+			State:       *ghPrReview.State,
+			User:        user,
+			SubmittedAt: ghPrReview.GetSubmittedAt(),
 		}
 	}
 
@@ -294,6 +301,12 @@ func (t *PullRequestTarget) GetCommits() ([]*codehost.Commit, error) {
 
 	for i, ghCommit := range ghCommits {
 		commits[i] = &codehost.Commit{
+			SHA: ghCommit.GetSHA(),
+			// The commit author may not be linked to a GitHub account, in which case the login is empty.
+			Author: &codehost.User{
+				Login: ghCommit.GetAuthor().GetLogin(),
+			},
+			AuthorName:   ghCommit.Commit.GetAuthor().GetName(),
 			Message:      *ghCommit.Commit.Message,
 			ParentsCount: len(ghCommit.Parents),
 		}
@@ -6,6 +6,7 @@ package codehost
 
 import (
 	"errors"
+	"time"
 
 	"github.com/reviewpad/reviewpad/v3/handler"
 )
@@ -65,10 +66,11 @@ type Reviewers struct {
 }
 
 type Review struct {
-	ID    int64
-	User  *User
-	Body  string
-	State string
+	ID          int64
+	User        *User
+	Body        string
+	State       string
+	SubmittedAt time.Time
 }
 
 type Project struct {
@@ -86,6 +88,11 @@ type ProjectField struct {
 	}
 }
 type Commit struct {
+	SHA string
+	// Author is the GitHub account of the author, whose login is empty when the commit is not linked to an account.
+	Author *User
+	// AuthorName is the name of the author in git.
+	AuthorName   string
 	Message      string
 	ParentsCount int
 }
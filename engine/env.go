@@ -25,6 +25,8 @@ const ExitStatusSuccess ExitStatus = 0
 const ExitStatusFailure ExitStatus = 1
 
 type Interpreter interface {
+	CallGraph(functions []PadFunction) CallGraph
+	ProcessFunction(name string, parameters []PadFunctionParameter, spec string) error
 	ProcessGroup(name string, kind GroupKind, typeOf GroupType, expr, paramExpr, whereExpr string) error
 	ProcessLabel(id, name string) error
 	ProcessRule(name, spec string) error
@@ -24,6 +24,11 @@ func execLog(val string) {
 	log.Println(fmtio.Sprint("reviewpad", val))
 }
 
+// specError prefixes err with the path in the reviewpad.yml file of the spec that caused it.
+func specError(path string, err error) error {
+	return fmt.Errorf("%v: %w", path, err)
+}
+
 func CollectError(env *Env, err error) {
 	var errMsg string
 	ghError, isGitHubError := err.(*github.ErrorResponse)
@@ -41,6 +46,73 @@ func CollectError(env *Env, err error) {
 	env.Collector.Collect("Error", collectedData)
 }
 
+// Register processes the labels, functions, groups and rules of the file in the interpreter of the environment,
+// without evaluating its workflows. The labels are not created in the repository.
+func Register(file *ReviewpadFile, env *Env) error {
+	interpreter := env.Interpreter
+
+	// process labels
+	for labelKeyName, label := range file.Labels {
+		labelName := labelKeyName
+		// for backwards compatibility, a label has both a key and a name
+		if label.Name != "" {
+			labelName = label.Name
+		}
+
+		err := interpreter.ProcessLabel(labelKeyName, labelName)
+		if err != nil {
+			return err
+		}
+	}
+
+	// process functions, each one after the functions it calls so that their types are known when it is type checked
+	functions, err := SortFunctions(file.Functions, interpreter.CallGraph(file.Functions))
+	if err != nil {
+		return err
+	}
+
+	functionsIndex := make(map[string]int)
+	for i, function := range file.Functions {
+		functionsIndex[function.Name] = i
+	}
+
+	for _, function := range functions {
+		err := interpreter.ProcessFunction(function.Name, function.Parameters, function.Spec)
+		if err != nil {
+			err = specError(fmt.Sprintf("functions[%v].spec", functionsIndex[function.Name]), err)
+			CollectError(env, err)
+			return err
+		}
+	}
+
+	// process groups
+	for i, group := range file.Groups {
+		err := interpreter.ProcessGroup(group.Name, GroupKind(group.Kind), GroupType(group.Type), group.Spec, group.Param, group.Where)
+		if err != nil {
+			specField := "spec"
+			if GroupType(group.Type) == GroupTypeFilter {
+				specField = "where"
+			}
+
+			err = specError(fmt.Sprintf("groups[%v].%v", i, specField), err)
+			CollectError(env, err)
+			return err
+		}
+	}
+
+	// process rules
+	for i, rule := range file.Rules {
+		err := interpreter.ProcessRule(rule.Name, rule.Spec)
+		if err != nil {
+			err = specError(fmt.Sprintf("rules[%v].spec", i), err)
+			CollectError(env, err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Eval: main function that generates the program to be executed
 // Pre-condition Lint(file) == nil
 func Eval(file *ReviewpadFile, env *Env) (*Program, error) {
@@ -53,6 +125,7 @@ func Eval(file *ReviewpadFile, env *Env) (*Program, error) {
 		"version":        file.Version,
 		"edition":        file.Edition,
 		"mode":           file.Mode,
+		"totalFunctions": len(file.Functions),
 		"totalGroups":    len(file.Groups),
 		"totalLabels":    len(file.Labels),
 		"totalRules":     len(file.Rules),
@@ -62,13 +135,16 @@ func Eval(file *ReviewpadFile, env *Env) (*Program, error) {
 	env.Collector.Collect("Trigger Analysis", collectedData)
 
 	rules := make(map[string]PadRule)
+	// rulesIndex keeps the position of each rule in the file to report errors on its spec.
+	rulesIndex := make(map[string]int)
 
+	execLogf("detected %v functions", len(file.Functions))
 	execLogf("detected %v groups", len(file.Groups))
 	execLogf("detected %v labels", len(file.Labels))
 	execLogf("detected %v rules", len(file.Rules))
 	execLogf("detected %v workflows", len(file.Workflows))
 
-	// process labels
+	// create the labels that do not exist yet in the repository
 	for labelKeyName, label := range file.Labels {
 		labelName := labelKeyName
 		// for backwards compatibility, a label has both a key and a name
@@ -90,30 +166,15 @@ func Eval(file *ReviewpadFile, env *Env) (*Program, error) {
 				}
 			}
 		}
-
-		err := interpreter.ProcessLabel(labelKeyName, labelName)
-		if err != nil {
-			return nil, err
-		}
 	}
 
-	// process groups
-	for _, group := range file.Groups {
-		err := interpreter.ProcessGroup(group.Name, GroupKind(group.Kind), GroupType(group.Type), group.Spec, group.Param, group.Where)
-		if err != nil {
-			CollectError(env, err)
-			return nil, err
-		}
+	if err := Register(file, env); err != nil {
+		return nil, err
 	}
 
-	// process rules
-	for _, rule := range file.Rules {
-		err := interpreter.ProcessRule(rule.Name, rule.Spec)
-		if err != nil {
-			CollectError(env, err)
-			return nil, err
-		}
+	for i, rule := range file.Rules {
 		rules[rule.Name] = rule
+		rulesIndex[rule.Name] = i
 	}
 
 	// a program is a list of statements to be executed based on the workflow rules and actions.
@@ -122,9 +183,10 @@ func Eval(file *ReviewpadFile, env *Env) (*Program, error) {
 	// triggeredExclusiveWorkflow is a control variable to denote if a workflow `always-run: false` has been triggered.
 	triggeredExclusiveWorkflow := false
 
-	for _, workflow := range file.Workflows {
+	for workflowIndex, workflow := range file.Workflows {
 		execLogf("evaluating workflow %v:", workflow.Name)
 
+		// The skipped workflows run neither their actions nor their else actions.
 		if !workflow.AlwaysRun && triggeredExclusiveWorkflow {
 			execLog("\tskipping workflow")
 			continue
@@ -146,12 +208,13 @@ func Eval(file *ReviewpadFile, env *Env) (*Program, error) {
 			continue
 		}
 
-		for _, rule := range workflow.Rules {
+		for ruleIndex, rule := range workflow.Rules {
 			ruleName := rule.Rule
 			ruleDefinition := rules[ruleName]
 
 			activated, err := interpreter.EvalExpr(ruleDefinition.Kind, ruleDefinition.Spec)
 			if err != nil {
+				err = specError(fmt.Sprintf("workflows[%v].if[%v]: rules[%v].spec", workflowIndex, ruleIndex, rulesIndex[ruleName]), err)
 				CollectError(env, err)
 				return nil, err
 			}
@@ -176,10 +239,13 @@ func Eval(file *ReviewpadFile, env *Env) (*Program, error) {
 			}
 		} else {
 			execLog("\tno rules activated")
+
+			// The else actions do not prevent the following workflows from running.
+			program.append(workflow.ElseActions)
 		}
 	}
 
-	for _, pipeline := range file.Pipelines {
+	for pipelineIndex, pipeline := range file.Pipelines {
 		execLogf("evaluating pipeline %v:", pipeline.Name)
 
 		var err error
@@ -187,6 +253,7 @@ func Eval(file *ReviewpadFile, env *Env) (*Program, error) {
 		if !activated {
 			activated, err = interpreter.EvalExpr("patch", pipeline.Trigger)
 			if err != nil {
+				err = specError(fmt.Sprintf("pipelines[%v].trigger", pipelineIndex), err)
 				CollectError(env, err)
 				return nil, err
 			}
@@ -202,6 +269,7 @@ func Eval(file *ReviewpadFile, env *Env) (*Program, error) {
 
 				isDone, err := interpreter.EvalExpr("patch", stage.Until)
 				if err != nil {
+					err = specError(fmt.Sprintf("pipelines[%v].stages[%v].until", pipelineIndex, num), err)
 					CollectError(env, err)
 					return nil, err
 				}
@@ -156,11 +156,23 @@ func TestEval(t *testing.T) {
 		"when group is invalid": {
 			inputReviewpadFilePath: "testdata/exec/reviewpad_with_invalid_group.yml",
 			clientOptions:          []mock.MockBackendOption{mockGetReposLabelsByOwnerByRepoByName("test-invalid-group")},
-			wantErr:                "ProcessGroup:evalGroup expression is not a valid group",
+			wantErr:                "groups[0].spec: ProcessGroup:evalGroup expression is not a valid group\n --> line 1, column 1\n  |\n1 | 2\n  | ^",
+		},
+		"when functions are valid": {
+			inputReviewpadFilePath: "testdata/exec/reviewpad_with_functions.yml",
+			wantProgram: engine.BuildProgram(
+				[]*engine.Statement{
+					engine.BuildStatement(`$addLabel("small")`),
+				},
+			),
+		},
+		"when function is invalid": {
+			inputReviewpadFilePath: "testdata/exec/reviewpad_with_invalid_function.yml",
+			wantErr:                "functions[0].spec: ProcessFunction:buildFunction: type inference failed\n --> line 1, column 1\n  |\n1 | $size < 10\n  | ^^^^^^^^^^",
 		},
 		"when workflow is invalid": {
 			inputReviewpadFilePath: "testdata/exec/reviewpad_with_invalid_workflow.yml",
-			wantErr:                "expression 1 is not a condition",
+			wantErr:                "workflows[0].if[0]: rules[0].spec: expression 1 is not a condition\n --> line 1, column 1\n  |\n1 | 1\n  | ^",
 		},
 		"when no workflow is activated": {
 			inputReviewpadFilePath: "testdata/exec/reviewpad_with_no_activated_workflows.yml",
@@ -194,6 +206,24 @@ func TestEval(t *testing.T) {
 				},
 			),
 		},
+		"when workflow has else actions": {
+			inputReviewpadFilePath: "testdata/exec/reviewpad_with_workflow_with_else_actions.yml",
+			wantProgram: engine.BuildProgram(
+				[]*engine.Statement{
+					engine.BuildStatement(`$removeLabel("waiting-review")`),
+					engine.BuildStatement(`$addLabel("activated-workflow")`),
+				},
+			),
+		},
+		"when workflow with else actions is skipped": {
+			inputReviewpadFilePath: "testdata/exec/reviewpad_with_skipped_workflow_with_else_actions.yml",
+			wantProgram: engine.BuildProgram(
+				[]*engine.Statement{
+					engine.BuildStatement(`$addLabel("activated-workflow")`),
+					engine.BuildStatement(`$removeLabel("always-run-workflow")`),
+				},
+			),
+		},
 		"when workflow is skipped": {
 			inputReviewpadFilePath: "testdata/exec/reviewpad_with_skipped_workflow.yml",
 			wantProgram: engine.BuildProgram(
@@ -238,6 +268,47 @@ func TestEval(t *testing.T) {
 	}
 }
 
+func TestRegister(t *testing.T) {
+	// Registering the file does not create its labels.
+	mockedClient := engine.MockGithubClient([]mock.MockBackendOption{
+		mock.WithRequestMatchHandler(
+			mock.GetReposLabelsByOwnerByRepoByName,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mock.WriteError(w, http.StatusInternalServerError, "GetLabelRequestFailed")
+			}),
+		),
+	})
+
+	mockedAladinoInterpreter, err := mockAladinoInterpreter(mockedClient)
+	if err != nil {
+		assert.FailNow(t, "mockDefaultAladinoInterpreterWith: %v", err)
+	}
+
+	mockedEnv, err := engine.MockEnvWith(mockedClient, mockedAladinoInterpreter)
+	if err != nil {
+		assert.FailNow(t, "engine MockDefaultEnvWith: %v", err)
+	}
+
+	reviewpadFile := &engine.ReviewpadFile{
+		Labels: map[string]engine.PadLabel{"bug": {Color: "f29513"}},
+		Functions: []engine.PadFunction{
+			{Name: "isSmall", Parameters: []engine.PadFunctionParameter{{Name: "size", Type: "Int"}}, Spec: `$isPositive($size) && $size < 10`},
+			{Name: "isPositive", Parameters: []engine.PadFunctionParameter{{Name: "value", Type: "Int"}}, Spec: `$value > 0`},
+		},
+		Rules: []engine.PadRule{
+			{Name: "small", Kind: "patch", Spec: `$isSmall(5)`},
+		},
+	}
+
+	err = engine.Register(reviewpadFile, mockedEnv)
+	assert.Nil(t, err)
+
+	gotVal, err := mockedAladinoInterpreter.EvalExpr("patch", `$isSmall(5)`)
+
+	assert.Nil(t, err)
+	assert.True(t, gotVal)
+}
+
 func mockAladinoInterpreter(githubClient *gh.GithubClient) (engine.Interpreter, error) {
 	dryRun := false
 	mockedAladinoInterpreter, err := aladino.NewInterpreter(
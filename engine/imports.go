@@ -0,0 +1,267 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package engine
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	gh "github.com/reviewpad/reviewpad/v3/codehost/github"
+	"gopkg.in/yaml.v3"
+)
+
+var sha256Pattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// ImportsLock is the lockfile of a reviewpad file, which pins the sha256 of its remote imports.
+type ImportsLock struct {
+	Imports []PadImport `yaml:"imports"`
+}
+
+// LockFilePath is the path of the lockfile of the reviewpad file at filePath, e.g. reviewpad.lock.yml for reviewpad.yml.
+func LockFilePath(filePath string) string {
+	ext := filepath.Ext(filePath)
+	return strings.TrimSuffix(filePath, ext) + ".lock" + ext
+}
+
+// LockImports loads the reviewpad file at filePath ignoring its lockfile
+// and pins the sha256 of the current content of its remote imports.
+func LockImports(ctx context.Context, githubClient *gh.GithubClient, filePath string, data []byte) (*ImportsLock, error) {
+	_, env, err := load(ctx, githubClient, filePath, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportsLock{Imports: env.Resolved}, nil
+}
+
+// WriteLock writes the lockfile of the reviewpad file at filePath.
+func WriteLock(filePath string, lock *ImportsLock) error {
+	var content bytes.Buffer
+	content.WriteString("# Generated by reviewpad imports lock. DO NOT EDIT.\n\n")
+
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(lock); err != nil {
+		return err
+	}
+
+	return os.WriteFile(LockFilePath(filePath), content.Bytes(), 0644)
+}
+
+// readLock reads the sha256 pinned in the lockfile of the reviewpad file at filePath by the location of the imports.
+// There are no pinned imports when the file has no lockfile.
+func readLock(filePath string) (map[importSource]string, error) {
+	lock := make(map[importSource]string)
+	if filePath == "" {
+		return lock, nil
+	}
+
+	content, err := os.ReadFile(LockFilePath(filePath))
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	importsLock := ImportsLock{}
+	if err := yaml.Unmarshal(content, &importsLock); err != nil {
+		return nil, fmt.Errorf("loader: invalid lockfile %v: %v", LockFilePath(filePath), err)
+	}
+
+	for _, lockedImport := range importsLock.Imports {
+		sha256 := lockedImport.Sha256
+		if !sha256Pattern.MatchString(sha256) {
+			return nil, fmt.Errorf("loader: invalid sha256 %v in lockfile %v", sha256, LockFilePath(filePath))
+		}
+
+		lock[lockedImport.source()] = sha256
+	}
+
+	return lock, nil
+}
+
+// importsCacheDir is the directory of the cache of the remote imports, e.g. ~/.cache/reviewpad/imports on Linux.
+// It is set by the REVIEWPAD_CACHE_DIR environment variable, the cache is disabled when there is no directory.
+func importsCacheDir() string {
+	if dir := os.Getenv("REVIEWPAD_CACHE_DIR"); dir != "" {
+		return dir
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "reviewpad", "imports")
+}
+
+// readCache reads the content with the given sha256 from the cache.
+func readCache(dir string, sha256 string) ([]byte, bool) {
+	if dir == "" || !sha256Pattern.MatchString(sha256) {
+		return nil, false
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, sha256))
+	// The cache can be changed outside of reviewpad.
+	if err != nil || hash(content) != sha256 {
+		return nil, false
+	}
+
+	return content, true
+}
+
+// writeCache writes the content to the cache by its sha256.
+// The cache is best effort, loading a file does not fail when the cache cannot be written.
+func writeCache(dir string, content []byte) {
+	if dir == "" {
+		return
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	_ = os.WriteFile(filepath.Join(dir, hash(content)), content, 0644)
+}
+
+// variablePattern matches the variables of the specs of an imported file, e.g. ${{ max-size }}.
+var variablePattern = regexp.MustCompile(`\$\{\{\s*([\w-]+)\s*\}\}`)
+
+// substitute replaces the variables of a spec by their values.
+func substitute(spec string, with map[string]string) (string, error) {
+	var err error
+
+	substituted := variablePattern.ReplaceAllStringFunc(spec, func(variable string) string {
+		name := variablePattern.FindStringSubmatch(variable)[1]
+
+		value, ok := with[name]
+		if !ok && err == nil {
+			err = fmt.Errorf("variable %v is not set", name)
+		}
+
+		return value
+	})
+
+	return substituted, err
+}
+
+func substituteAll(specs []string, with map[string]string) error {
+	for i, spec := range specs {
+		substituted, err := substitute(spec, with)
+		if err != nil {
+			return err
+		}
+
+		specs[i] = substituted
+	}
+
+	return nil
+}
+
+// substituteVariables replaces the variables of the specs and actions of an imported file by the values of the import.
+func substituteVariables(file *ReviewpadFile, with map[string]string) error {
+	var err error
+
+	for i := range file.Functions {
+		if file.Functions[i].Spec, err = substitute(file.Functions[i].Spec, with); err != nil {
+			return err
+		}
+	}
+
+	for i := range file.Groups {
+		if file.Groups[i].Spec, err = substitute(file.Groups[i].Spec, with); err != nil {
+			return err
+		}
+
+		if file.Groups[i].Where, err = substitute(file.Groups[i].Where, with); err != nil {
+			return err
+		}
+	}
+
+	for i := range file.Rules {
+		if file.Rules[i].Spec, err = substitute(file.Rules[i].Spec, with); err != nil {
+			return err
+		}
+	}
+
+	for i := range file.Workflows {
+		if err := substituteAll(file.Workflows[i].Actions, with); err != nil {
+			return err
+		}
+
+		if err := substituteAll(file.Workflows[i].ElseActions, with); err != nil {
+			return err
+		}
+
+		// The rules of the workflows are not normalized yet, see processInlineRules.
+		for j, rawRule := range file.Workflows[i].NonNormalizedRules {
+			if file.Workflows[i].NonNormalizedRules[j], err = substituteRawRule(rawRule, with); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range file.Pipelines {
+		if file.Pipelines[i].Trigger, err = substitute(file.Pipelines[i].Trigger, with); err != nil {
+			return err
+		}
+
+		for j := range file.Pipelines[i].Stages {
+			stage := &file.Pipelines[i].Stages[j]
+			if err := substituteAll(stage.Actions, with); err != nil {
+				return err
+			}
+
+			if stage.Until, err = substitute(stage.Until, with); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// substituteRawRule replaces the variables of a rule of a workflow, which is either
+// the name of a rule, an inline spec or a rule with extra actions.
+func substituteRawRule(rawRule interface{}, with map[string]string) (interface{}, error) {
+	switch r := rawRule.(type) {
+	case string:
+		return substitute(r, with)
+	case map[string]interface{}:
+		substituted := make(map[string]interface{}, len(r))
+		for key, value := range r {
+			substitutedValue, err := substituteRawRule(value, with)
+			if err != nil {
+				return nil, err
+			}
+
+			substituted[key] = substitutedValue
+		}
+
+		return substituted, nil
+	case []interface{}:
+		substituted := make([]interface{}, len(r))
+		for i, value := range r {
+			substitutedValue, err := substituteRawRule(value, with)
+			if err != nil {
+				return nil, err
+			}
+
+			substituted[i] = substitutedValue
+		}
+
+		return substituted, nil
+	}
+
+	// The invalid rules are reported by processInlineRules.
+	return rawRule, nil
+}
@@ -0,0 +1,193 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package engine_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+var noImportsSha256 = fileSha256("testdata/loader/reviewpad_with_no_imports.yml")
+
+func fileSha256(filePath string) string {
+	return fmt.Sprintf("%x", sha256.Sum256(httpmock.File(filePath).Bytes()))
+}
+
+// copyReviewpadFile copies the reviewpad file to a temporary directory, where its lockfile can be written.
+func copyReviewpadFile(t *testing.T, filePath string) (string, []byte) {
+	data := httpmock.File(filePath).Bytes()
+	copyPath := filepath.Join(t.TempDir(), "reviewpad.yml")
+
+	if err := os.WriteFile(copyPath, data, 0644); err != nil {
+		assert.FailNow(t, "Error writing reviewpad file: %v", err)
+	}
+
+	return copyPath, data
+}
+
+func TestLockFilePath(t *testing.T) {
+	assert.Equal(t, "reviewpad.lock.yml", engine.LockFilePath("reviewpad.yml"))
+	assert.Equal(t, filepath.Join(".github", "reviewpad.lock.yaml"), engine.LockFilePath(filepath.Join(".github", "reviewpad.yaml")))
+}
+
+func TestLockImports(t *testing.T) {
+	t.Setenv("REVIEWPAD_CACHE_DIR", t.TempDir())
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerHttpResponders([]httpMockResponder{
+		{
+			url:       "https://foo.bar/reviewpad_with_no_imports.yml",
+			responder: httpmock.NewBytesResponder(200, httpmock.File("testdata/loader/reviewpad_with_no_imports.yml").Bytes()),
+		},
+		{
+			url:       "https://foo.bar/reviewpad_with_one_import.yml",
+			responder: httpmock.NewBytesResponder(200, httpmock.File("testdata/loader/reviewpad_with_one_import.yml").Bytes()),
+		},
+	})
+
+	filePath, data := copyReviewpadFile(t, "testdata/loader/reviewpad_with_imports_chain.yml")
+
+	gotLock, err := engine.LockImports(context.Background(), nil, filePath, data)
+
+	// The file imported twice is pinned once.
+	wantLock := &engine.ImportsLock{
+		Imports: []engine.PadImport{
+			{Url: "https://foo.bar/reviewpad_with_no_imports.yml", Sha256: noImportsSha256},
+			{Url: "https://foo.bar/reviewpad_with_one_import.yml", Sha256: fileSha256("testdata/loader/reviewpad_with_one_import.yml")},
+		},
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantLock, gotLock)
+}
+
+func TestLockImports_WhenImportIsNotFound(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv("REVIEWPAD_CACHE_DIR", cacheDir)
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	registerHttpResponders([]httpMockResponder{
+		{
+			url:       "https://foo.bar/nonexistent_file",
+			responder: httpmock.NewStringResponder(404, "404: Not Found"),
+		},
+	})
+
+	filePath, data := copyReviewpadFile(t, "testdata/loader/reviewpad_with_import_of_nonexistent_file.yml")
+
+	gotLock, err := engine.LockImports(context.Background(), nil, filePath, data)
+
+	assert.EqualError(t, err, "loader: import of https://foo.bar/nonexistent_file failed with status 404")
+	assert.Nil(t, gotLock)
+
+	cached, err := os.ReadDir(cacheDir)
+	assert.Nil(t, err)
+	assert.Empty(t, cached)
+}
+
+func TestLoadFile_WhenImportsAreLocked(t *testing.T) {
+	tests := map[string]struct {
+		lock       *engine.ImportsLock
+		responders []httpMockResponder
+		wantErr    string
+	}{
+		"when the content of the imports is cached": {
+			responders: []httpMockResponder{
+				{
+					url:       "https://foo.bar/reviewpad_with_no_imports.yml",
+					responder: httpmock.NewErrorResponder(fmt.Errorf("offline")),
+				},
+				{
+					url:       "https://foo.bar/reviewpad_with_one_import.yml",
+					responder: httpmock.NewErrorResponder(fmt.Errorf("offline")),
+				},
+			},
+		},
+		"when the content of an import changed": {
+			lock: &engine.ImportsLock{
+				Imports: []engine.PadImport{
+					{Url: "https://foo.bar/reviewpad_with_no_imports.yml", Sha256: fileSha256("testdata/loader/reviewpad_with_one_import.yml")},
+				},
+			},
+			responders: []httpMockResponder{
+				{
+					url:       "https://foo.bar/reviewpad_with_no_imports.yml",
+					responder: httpmock.NewBytesResponder(200, httpmock.File("testdata/loader/reviewpad_with_no_imports.yml").Bytes()),
+				},
+			},
+			wantErr: fmt.Sprintf("loader: import of https://foo.bar/reviewpad_with_no_imports.yml has sha256 %v, expected %v", noImportsSha256, fileSha256("testdata/loader/reviewpad_with_one_import.yml")),
+		},
+		"when the lockfile has an invalid sha256": {
+			lock: &engine.ImportsLock{
+				Imports: []engine.PadImport{
+					{Url: "https://foo.bar/reviewpad_with_no_imports.yml", Sha256: "abc"},
+				},
+			},
+			wantErr: "loader: invalid sha256 abc in lockfile",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("REVIEWPAD_CACHE_DIR", t.TempDir())
+
+			filePath, data := copyReviewpadFile(t, "testdata/loader/reviewpad_with_imports_chain.yml")
+
+			httpmock.Activate()
+			defer httpmock.DeactivateAndReset()
+
+			// Locking the imports caches their content.
+			registerHttpResponders([]httpMockResponder{
+				{
+					url:       "https://foo.bar/reviewpad_with_no_imports.yml",
+					responder: httpmock.NewBytesResponder(200, httpmock.File("testdata/loader/reviewpad_with_no_imports.yml").Bytes()),
+				},
+				{
+					url:       "https://foo.bar/reviewpad_with_one_import.yml",
+					responder: httpmock.NewBytesResponder(200, httpmock.File("testdata/loader/reviewpad_with_one_import.yml").Bytes()),
+				},
+			})
+
+			lock, err := engine.LockImports(context.Background(), nil, filePath, data)
+			if err != nil {
+				assert.FailNow(t, "Error locking imports: %v", err)
+			}
+
+			if test.lock != nil {
+				lock = test.lock
+				t.Setenv("REVIEWPAD_CACHE_DIR", t.TempDir())
+			}
+
+			if err := engine.WriteLock(filePath, lock); err != nil {
+				assert.FailNow(t, "Error writing lockfile: %v", err)
+			}
+
+			registerHttpResponders(test.responders)
+
+			gotReviewpadFile, gotErr := engine.LoadFile(context.Background(), nil, filePath, data)
+
+			if test.wantErr != "" {
+				assert.ErrorContains(t, gotErr, test.wantErr)
+				assert.Nil(t, gotReviewpadFile)
+				return
+			}
+
+			assert.Nil(t, gotErr)
+			assert.NotNil(t, gotReviewpadFile)
+		})
+	}
+}
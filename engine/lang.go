@@ -4,7 +4,15 @@
 
 package engine
 
-import "github.com/reviewpad/reviewpad/v3/handler"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/reviewpad/reviewpad/v3/handler"
+)
+
+// API_VERSION is the current version of the reviewpad file format, e.g. the version of its schema.
+const API_VERSION string = "reviewpad.com/v3.x"
 
 const (
 	PROFESSIONAL_EDITION string = "professional"
@@ -13,19 +21,77 @@ const (
 	VERBOSE_MODE         string = "verbose"
 )
 
+// PadImport is the location of an imported reviewpad file, which is either:
+// - an url, e.g. url: https://foo.bar/reviewpad.yml
+// - a path relative to the importing file, e.g. path: ./reviewpad/common.yml
+// - a path in a repository at a ref, e.g. repo: reviewpad/reviewpad, path: reviewpad.yml, ref: v3.0.0
+// The content of any import can be pinned by its sha256.
+// The specs of the imported file are given the variables of the import, written as ${{ name }} in the specs,
+// and the labels, functions, groups, rules, workflows and pipelines that are excluded by name are not imported.
 type PadImport struct {
-	Url string `yaml:"url"`
+	Url  string `yaml:"url,omitempty"`
+	Path string `yaml:"path,omitempty"`
+	// Repo is the repository of the imported file, e.g. reviewpad/reviewpad.
+	Repo string `yaml:"repo,omitempty"`
+	// Ref is the branch, tag or commit of Repo, the default branch of Repo when empty.
+	Ref string `yaml:"ref,omitempty"`
+	// Sha256 is the hex encoded sha256 the content of the imported file must have.
+	Sha256 string `yaml:"sha256,omitempty"`
+	// With are the values of the variables of the imported file by name.
+	With    map[string]string `yaml:"with,omitempty"`
+	Exclude []string          `yaml:"exclude,omitempty"`
+}
+
+// importSource identifies the file of an import.
+type importSource struct {
+	Url  string
+	Path string
+	Repo string
+	Ref  string
+}
+
+func (p PadImport) source() importSource {
+	return importSource{Url: p.Url, Path: p.Path, Repo: p.Repo, Ref: p.Ref}
 }
 
 func (p PadImport) equals(o PadImport) bool {
-	return p.Url == o.Url
+	if p.source() != o.source() {
+		return false
+	}
+
+	if p.Sha256 != o.Sha256 {
+		return false
+	}
+
+	if len(p.With) != len(o.With) {
+		return false
+	}
+
+	for name, pW := range p.With {
+		oW, ok := o.With[name]
+		if !ok || pW != oW {
+			return false
+		}
+	}
+
+	if len(p.Exclude) != len(o.Exclude) {
+		return false
+	}
+
+	for i, pE := range p.Exclude {
+		if pE != o.Exclude[i] {
+			return false
+		}
+	}
+
+	return true
 }
 
 type PadRule struct {
-	Name        string `yaml:"name"`
-	Kind        string `yaml:"kind"`
-	Description string `yaml:"description"`
-	Spec        string `yaml:"spec"`
+	Name        string `yaml:"name,omitempty"`
+	Kind        string `yaml:"kind,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Spec        string `yaml:"spec,omitempty"`
 }
 
 func (p PadRule) equals(o PadRule) bool {
@@ -50,9 +116,59 @@ func (p PadRule) equals(o PadRule) bool {
 
 var kinds = []string{"patch", "author"}
 
+type PadFunctionParameter struct {
+	Name string `yaml:"name,omitempty"`
+	Type string `yaml:"type,omitempty"`
+}
+
+func (p PadFunctionParameter) equals(o PadFunctionParameter) bool {
+	if p.Name != o.Name {
+		return false
+	}
+
+	if p.Type != o.Type {
+		return false
+	}
+
+	return true
+}
+
+type PadFunction struct {
+	Name        string                 `yaml:"name,omitempty"`
+	Description string                 `yaml:"description,omitempty"`
+	Parameters  []PadFunctionParameter `yaml:"parameters,omitempty"`
+	Spec        string                 `yaml:"spec,omitempty"`
+}
+
+func (p PadFunction) equals(o PadFunction) bool {
+	if p.Name != o.Name {
+		return false
+	}
+
+	if p.Description != o.Description {
+		return false
+	}
+
+	if len(p.Parameters) != len(o.Parameters) {
+		return false
+	}
+	for i, pP := range p.Parameters {
+		oP := o.Parameters[i]
+		if !pP.equals(oP) {
+			return false
+		}
+	}
+
+	if p.Spec != o.Spec {
+		return false
+	}
+
+	return true
+}
+
 type PadWorkflowRule struct {
-	Rule         string   `yaml:"rule"`
-	ExtraActions []string `yaml:"extra-actions" mapstructure:"extra-actions"`
+	Rule         string   `yaml:"rule,omitempty"`
+	ExtraActions []string `yaml:"extra-actions,omitempty" mapstructure:"extra-actions"`
 }
 
 func (p PadWorkflowRule) equals(o PadWorkflowRule) bool {
@@ -74,9 +190,9 @@ func (p PadWorkflowRule) equals(o PadWorkflowRule) bool {
 }
 
 type PadLabel struct {
-	Name        string `yaml:"name"`
-	Color       string `yaml:"color"`
-	Description string `yaml:"description"`
+	Name        string `yaml:"name,omitempty"`
+	Color       string `yaml:"color,omitempty"`
+	Description string `yaml:"description,omitempty"`
 }
 
 func (p PadLabel) equals(o PadLabel) bool {
@@ -96,13 +212,17 @@ func (p PadLabel) equals(o PadLabel) bool {
 }
 
 type PadWorkflow struct {
-	Name               string                     `yaml:"name"`
-	On                 []handler.TargetEntityKind `yaml:"on"`
-	Description        string                     `yaml:"description"`
-	AlwaysRun          bool                       `yaml:"always-run"`
-	Rules              []PadWorkflowRule          `yaml:"-"`
-	Actions            []string                   `yaml:"then"`
-	NonNormalizedRules []interface{}              `yaml:"if"`
+	Name        string                     `yaml:"name,omitempty"`
+	On          []handler.TargetEntityKind `yaml:"on,omitempty"`
+	Description string                     `yaml:"description,omitempty"`
+	AlwaysRun   bool                       `yaml:"always-run,omitempty"`
+	Rules       []PadWorkflowRule          `yaml:"-"`
+	Actions     []string                   `yaml:"then,omitempty"`
+	// ElseActions are run when the rules of the workflow are evaluated and none of them is activated.
+	// A workflow that is skipped, because of its event kind or because an earlier workflow with always-run false was activated,
+	// is not evaluated so its else actions are not run.
+	ElseActions        []string      `yaml:"else,omitempty"`
+	NonNormalizedRules []interface{} `yaml:"if,omitempty"`
 }
 
 func (p PadWorkflow) equals(o PadWorkflow) bool {
@@ -151,17 +271,28 @@ func (p PadWorkflow) equals(o PadWorkflow) bool {
 		}
 	}
 
+	if len(p.ElseActions) != len(o.ElseActions) {
+		return false
+	}
+
+	for i, pA := range p.ElseActions {
+		oA := o.ElseActions[i]
+		if pA != oA {
+			return false
+		}
+	}
+
 	return true
 }
 
 type PadGroup struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
-	Kind        string `yaml:"kind"`
-	Type        string `yaml:"type"`
-	Spec        string `yaml:"spec"`
-	Param       string `yaml:"param"`
-	Where       string `yaml:"where"`
+	Name        string `yaml:"name,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	Kind        string `yaml:"kind,omitempty"`
+	Type        string `yaml:"type,omitempty"`
+	Spec        string `yaml:"spec,omitempty"`
+	Param       string `yaml:"param,omitempty"`
+	Where       string `yaml:"where,omitempty"`
 }
 
 func (p PadGroup) equals(o PadGroup) bool {
@@ -194,27 +325,73 @@ func (p PadGroup) equals(o PadGroup) bool {
 
 type ReviewpadFile struct {
 	Version      string              `yaml:"api-version"`
-	Edition      string              `yaml:"edition"`
-	Mode         string              `yaml:"mode"`
-	IgnoreErrors bool                `yaml:"ignore-errors"`
-	Imports      []PadImport         `yaml:"imports"`
-	Groups       []PadGroup          `yaml:"groups"`
-	Rules        []PadRule           `yaml:"rules"`
-	Labels       map[string]PadLabel `yaml:"labels"`
-	Workflows    []PadWorkflow       `yaml:"workflows"`
-	Pipelines    []PadPipeline       `yaml:"pipelines"`
+	Edition      string              `yaml:"edition,omitempty"`
+	Mode         string              `yaml:"mode,omitempty"`
+	IgnoreErrors bool                `yaml:"ignore-errors,omitempty"`
+	Imports      []PadImport         `yaml:"imports,omitempty"`
+	Functions    []PadFunction       `yaml:"functions,omitempty"`
+	Groups       []PadGroup          `yaml:"groups,omitempty"`
+	Rules        []PadRule           `yaml:"rules,omitempty"`
+	Labels       map[string]PadLabel `yaml:"labels,omitempty"`
+	Workflows    []PadWorkflow       `yaml:"workflows,omitempty"`
+	Pipelines    []PadPipeline       `yaml:"pipelines,omitempty"`
 }
 
 type PadPipeline struct {
-	Name        string     `yaml:"name"`
-	Description string     `yaml:"description"`
-	Trigger     string     `yaml:"trigger"`
-	Stages      []PadStage `yaml:"stages"`
+	Name        string     `yaml:"name,omitempty"`
+	Description string     `yaml:"description,omitempty"`
+	Trigger     string     `yaml:"trigger,omitempty"`
+	Stages      []PadStage `yaml:"stages,omitempty"`
 }
 
 type PadStage struct {
-	Actions []string `yaml:"actions"`
-	Until   string   `yaml:"until"`
+	Actions []string `yaml:"actions,omitempty"`
+	Until   string   `yaml:"until,omitempty"`
+}
+
+func (p PadPipeline) equals(o PadPipeline) bool {
+	if p.Name != o.Name {
+		return false
+	}
+
+	if p.Description != o.Description {
+		return false
+	}
+
+	if p.Trigger != o.Trigger {
+		return false
+	}
+
+	if len(p.Stages) != len(o.Stages) {
+		return false
+	}
+
+	for i, pS := range p.Stages {
+		oS := o.Stages[i]
+		if !pS.equals(oS) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p PadStage) equals(o PadStage) bool {
+	if p.Until != o.Until {
+		return false
+	}
+
+	if len(p.Actions) != len(o.Actions) {
+		return false
+	}
+
+	for i, pA := range p.Actions {
+		if pA != o.Actions[i] {
+			return false
+		}
+	}
+
+	return true
 }
 
 func (r *ReviewpadFile) equals(o *ReviewpadFile) bool {
@@ -244,6 +421,16 @@ func (r *ReviewpadFile) equals(o *ReviewpadFile) bool {
 		}
 	}
 
+	if len(r.Functions) != len(o.Functions) {
+		return false
+	}
+	for i, rF := range r.Functions {
+		oF := o.Functions[i]
+		if !rF.equals(oF) {
+			return false
+		}
+	}
+
 	if len(r.Rules) != len(o.Rules) {
 		return false
 	}
@@ -285,41 +472,296 @@ func (r *ReviewpadFile) equals(o *ReviewpadFile) bool {
 		}
 	}
 
+	if len(r.Pipelines) != len(o.Pipelines) {
+		return false
+	}
+	for i, rP := range r.Pipelines {
+		oP := o.Pipelines[i]
+		if !rP.equals(oP) {
+			return false
+		}
+	}
+
 	return true
 }
 
-func (r *ReviewpadFile) appendLabels(o *ReviewpadFile) {
+// padNames are the names of the labels, functions, groups, rules, workflows and pipelines of a reviewpad file.
+type padNames struct {
+	labels    map[string]bool
+	functions map[string]bool
+	groups    map[string]bool
+	rules     map[string]bool
+	workflows map[string]bool
+	pipelines map[string]bool
+}
+
+func (r *ReviewpadFile) names() padNames {
+	names := padNames{
+		labels:    make(map[string]bool),
+		functions: make(map[string]bool),
+		groups:    make(map[string]bool),
+		rules:     make(map[string]bool),
+		workflows: make(map[string]bool),
+		pipelines: make(map[string]bool),
+	}
+
+	for labelName := range r.Labels {
+		names.labels[labelName] = true
+	}
+
+	for _, function := range r.Functions {
+		names.functions[function.Name] = true
+	}
+
+	for _, group := range r.Groups {
+		names.groups[group.Name] = true
+	}
+
+	for _, rule := range r.Rules {
+		names.rules[rule.Name] = true
+	}
+
+	for _, workflow := range r.Workflows {
+		names.workflows[workflow.Name] = true
+	}
+
+	for _, pipeline := range r.Pipelines {
+		names.pipelines[pipeline.Name] = true
+	}
+
+	return names
+}
+
+func (n padNames) has(name string) bool {
+	return n.labels[name] || n.functions[name] || n.groups[name] || n.rules[name] || n.workflows[name] || n.pipelines[name]
+}
+
+// The append functions append the definitions of an imported file.
+// An imported definition overrides the definition with the same name of a previous import,
+// but not the definition with the same name of the importing file, whose names are own.
+
+func (r *ReviewpadFile) appendLabels(o *ReviewpadFile, own map[string]bool) {
 	if r.Labels == nil {
 		r.Labels = make(map[string]PadLabel)
 	}
 
 	for labelName, label := range o.Labels {
-		r.Labels[labelName] = label
+		if !own[labelName] {
+			r.Labels[labelName] = label
+		}
 	}
 }
 
-func (r *ReviewpadFile) appendRules(o *ReviewpadFile) {
+func (r *ReviewpadFile) appendRules(o *ReviewpadFile, own map[string]bool) {
 	if r.Rules == nil {
 		r.Rules = make([]PadRule, 0)
 	}
 
-	r.Rules = append(r.Rules, o.Rules...)
+	for _, rule := range o.Rules {
+		if own[rule.Name] {
+			continue
+		}
+
+		if i := indexOfRule(r.Rules, rule.Name); i >= 0 {
+			r.Rules[i] = rule
+		} else {
+			r.Rules = append(r.Rules, rule)
+		}
+	}
+}
+
+func (r *ReviewpadFile) appendFunctions(o *ReviewpadFile, own map[string]bool) {
+	if r.Functions == nil {
+		r.Functions = make([]PadFunction, 0)
+	}
+
+	for _, function := range o.Functions {
+		if own[function.Name] {
+			continue
+		}
+
+		if i := indexOfFunction(r.Functions, function.Name); i >= 0 {
+			r.Functions[i] = function
+		} else {
+			r.Functions = append(r.Functions, function)
+		}
+	}
 }
 
-func (r *ReviewpadFile) appendGroups(o *ReviewpadFile) {
+func (r *ReviewpadFile) appendGroups(o *ReviewpadFile, own map[string]bool) {
 	if r.Groups == nil {
 		r.Groups = make([]PadGroup, 0)
 	}
 
-	r.Groups = append(r.Groups, o.Groups...)
+	for _, group := range o.Groups {
+		if own[group.Name] {
+			continue
+		}
+
+		if i := indexOfGroup(r.Groups, group.Name); i >= 0 {
+			r.Groups[i] = group
+		} else {
+			r.Groups = append(r.Groups, group)
+		}
+	}
 }
 
-func (r *ReviewpadFile) appendWorkflows(o *ReviewpadFile) {
+func (r *ReviewpadFile) appendWorkflows(o *ReviewpadFile, own map[string]bool) {
 	if r.Workflows == nil {
 		r.Workflows = make([]PadWorkflow, 0)
 	}
 
-	r.Workflows = append(r.Workflows, o.Workflows...)
+	for _, workflow := range o.Workflows {
+		if own[workflow.Name] {
+			continue
+		}
+
+		if i := indexOfWorkflow(r.Workflows, workflow.Name); i >= 0 {
+			r.Workflows[i] = workflow
+		} else {
+			r.Workflows = append(r.Workflows, workflow)
+		}
+	}
+}
+
+func (r *ReviewpadFile) appendPipelines(o *ReviewpadFile, own map[string]bool) {
+	if r.Pipelines == nil {
+		r.Pipelines = make([]PadPipeline, 0)
+	}
+
+	for _, pipeline := range o.Pipelines {
+		// An unnamed pipeline cannot be overridden by name, so it is always imported.
+		if pipeline.Name == "" {
+			r.Pipelines = append(r.Pipelines, pipeline)
+			continue
+		}
+
+		if own[pipeline.Name] {
+			continue
+		}
+
+		if i := indexOfPipeline(r.Pipelines, pipeline.Name); i >= 0 {
+			r.Pipelines[i] = pipeline
+		} else {
+			r.Pipelines = append(r.Pipelines, pipeline)
+		}
+	}
+}
+
+// appendSettings takes the edition and the mode of an imported file unless the importing file has its own.
+// The importing file does not ignore errors because of its imports, so ignore-errors is not imported.
+func (r *ReviewpadFile) appendSettings(o *ReviewpadFile, ownEdition string, ownMode string) {
+	if ownEdition == "" && o.Edition != "" {
+		r.Edition = o.Edition
+	}
+
+	if ownMode == "" && o.Mode != "" {
+		r.Mode = o.Mode
+	}
+}
+
+// exclude removes the labels, functions, groups, rules, workflows and pipelines with the given names.
+func (r *ReviewpadFile) exclude(names []string) {
+	excluded := make(map[string]bool)
+	for _, name := range names {
+		excluded[name] = true
+	}
+
+	for labelName := range r.Labels {
+		if excluded[labelName] {
+			delete(r.Labels, labelName)
+		}
+	}
+
+	functions := make([]PadFunction, 0)
+	for _, function := range r.Functions {
+		if !excluded[function.Name] {
+			functions = append(functions, function)
+		}
+	}
+	r.Functions = functions
+
+	groups := make([]PadGroup, 0)
+	for _, group := range r.Groups {
+		if !excluded[group.Name] {
+			groups = append(groups, group)
+		}
+	}
+	r.Groups = groups
+
+	rules := make([]PadRule, 0)
+	for _, rule := range r.Rules {
+		if !excluded[rule.Name] {
+			rules = append(rules, rule)
+		}
+	}
+	r.Rules = rules
+
+	workflows := make([]PadWorkflow, 0)
+	for _, workflow := range r.Workflows {
+		if !excluded[workflow.Name] {
+			workflows = append(workflows, workflow)
+		}
+	}
+	r.Workflows = workflows
+
+	pipelines := make([]PadPipeline, 0)
+	for _, pipeline := range r.Pipelines {
+		if !excluded[pipeline.Name] {
+			pipelines = append(pipelines, pipeline)
+		}
+	}
+	r.Pipelines = pipelines
+}
+
+func indexOfRule(rules []PadRule, name string) int {
+	for i, rule := range rules {
+		if rule.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func indexOfFunction(functions []PadFunction, name string) int {
+	for i, function := range functions {
+		if function.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func indexOfGroup(groups []PadGroup, name string) int {
+	for i, group := range groups {
+		if group.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func indexOfWorkflow(workflows []PadWorkflow, name string) int {
+	for i, workflow := range workflows {
+		if workflow.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func indexOfPipeline(pipelines []PadPipeline, name string) int {
+	for i, pipeline := range pipelines {
+		if pipeline.Name == name {
+			return i
+		}
+	}
+
+	return -1
 }
 
 func findGroup(groups []PadGroup, name string) (*PadGroup, bool) {
@@ -341,3 +783,66 @@ func findRule(rules []PadRule, name string) (*PadRule, bool) {
 
 	return nil, false
 }
+
+func findFunction(functions []PadFunction, name string) (*PadFunction, bool) {
+	for _, function := range functions {
+		if function.Name == name {
+			return &function, true
+		}
+	}
+
+	return nil, false
+}
+
+// CallGraph has, by the name of each function of a reviewpad file, the names of the functions of the file it calls.
+// The specs of the functions are parsed by the interpreter to build it, see Interpreter.CallGraph.
+type CallGraph map[string][]string
+
+// SortFunctions orders the functions so that every function comes after the functions it calls.
+// It fails if a function calls itself, either directly or through other functions.
+func SortFunctions(functions []PadFunction, callGraph CallGraph) ([]PadFunction, error) {
+	sortedFunctions := make([]PadFunction, 0, len(functions))
+	visited := make(map[string]bool)
+	// path is the chain of calls being visited, used to detect and report recursion.
+	path := make([]string, 0)
+
+	var visit func(function PadFunction) error
+	visit = func(function PadFunction) error {
+		for i, name := range path {
+			if name == function.Name {
+				return fmt.Errorf("function %v is recursive: %v", function.Name, strings.Join(append(path[i:], name), " -> "))
+			}
+		}
+
+		if visited[function.Name] {
+			return nil
+		}
+
+		path = append(path, function.Name)
+
+		for _, call := range callGraph[function.Name] {
+			calledFunction, ok := findFunction(functions, call)
+			if !ok {
+				continue
+			}
+
+			if err := visit(*calledFunction); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		visited[function.Name] = true
+		sortedFunctions = append(sortedFunctions, function)
+
+		return nil
+	}
+
+	for _, function := range functions {
+		if err := visit(function); err != nil {
+			return nil, err
+		}
+	}
+
+	return sortedFunctions, nil
+}
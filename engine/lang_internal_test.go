@@ -19,6 +19,16 @@ var mockedReviewpadFile = &ReviewpadFile{
 	Imports: []PadImport{
 		{Url: "https://foo.bar/draft-rule.yml"},
 	},
+	Functions: []PadFunction{
+		{
+			Name:        "isSmall",
+			Description: "Checks if a size is small",
+			Parameters: []PadFunctionParameter{
+				{Name: "size", Type: "Int"},
+			},
+			Spec: "$size < 10",
+		},
+	},
 	Groups: []PadGroup{
 		{
 			Name:        "seniors",
@@ -61,15 +71,15 @@ var mockedReviewpadFile = &ReviewpadFile{
 }
 
 func TestEquals_WhenPadImportsAreEqual(t *testing.T) {
-	padImport := PadImport{"http://foo.bar"}
-	otherPadImport := PadImport{"http://foo.bar"}
+	padImport := PadImport{Url: "http://foo.bar"}
+	otherPadImport := PadImport{Url: "http://foo.bar"}
 
 	assert.True(t, padImport.equals(otherPadImport))
 }
 
 func TestEquals_WhenPadImportsAreDiff(t *testing.T) {
-	padImport := PadImport{"http://foo.bar1"}
-	otherPadImport := PadImport{"http://foo.bar2"}
+	padImport := PadImport{Url: "http://foo.bar1"}
+	otherPadImport := PadImport{Url: "http://foo.bar2"}
 
 	assert.False(t, padImport.equals(otherPadImport))
 }
@@ -164,6 +174,62 @@ func TestEquals_WhenPadRulesHaveDiffSpec(t *testing.T) {
 	assert.False(t, padRule.equals(otherPadRule))
 }
 
+func TestEquals_WhenPadFunctionsAreEqual(t *testing.T) {
+	padFunction := PadFunction{
+		Name:        "isSmall",
+		Description: "Checks if a size is small",
+		Parameters: []PadFunctionParameter{
+			{Name: "size", Type: "Int"},
+		},
+		Spec: "$size < 10",
+	}
+
+	otherPadFunction := PadFunction{
+		Name:        "isSmall",
+		Description: "Checks if a size is small",
+		Parameters: []PadFunctionParameter{
+			{Name: "size", Type: "Int"},
+		},
+		Spec: "$size < 10",
+	}
+
+	assert.True(t, padFunction.equals(otherPadFunction))
+}
+
+func TestEquals_WhenPadFunctionsHaveDiffParameters(t *testing.T) {
+	padFunction := PadFunction{
+		Name: "isSmall",
+		Parameters: []PadFunctionParameter{
+			{Name: "size", Type: "Int"},
+		},
+		Spec: "$size < 10",
+	}
+
+	otherPadFunction := PadFunction{
+		Name: "isSmall",
+		Parameters: []PadFunctionParameter{
+			{Name: "size", Type: "String"},
+		},
+		Spec: "$size < 10",
+	}
+
+	assert.False(t, padFunction.equals(otherPadFunction))
+}
+
+func TestEquals_WhenPadFunctionsHaveDiffSpec(t *testing.T) {
+	padFunction := PadFunction{
+		Name: "isSmall",
+		Spec: "$size() < 10",
+	}
+
+	otherPadFunction := PadFunction{
+		Name: "isSmall",
+		Spec: "$size() < 20",
+	}
+
+	assert.False(t, padFunction.equals(otherPadFunction))
+}
+
 func TestEquals_WhenPadWorkflowRulesAreEqual(t *testing.T) {
 	padWorkflowRule := PadWorkflowRule{
 		Rule: "test-rule",
@@ -821,6 +887,23 @@ func TestEquals_WhenReviewpadFilesHaveDiffRules(t *testing.T) {
 	assert.False(t, mockedReviewpadFile.equals(otherReviewpadFile))
 }
 
+func TestEquals_WhenReviewpadFilesHaveDiffFunctions(t *testing.T) {
+	otherReviewpadFile := &ReviewpadFile{}
+	copier.Copy(otherReviewpadFile, mockedReviewpadFile)
+
+	otherReviewpadFile.Functions = []PadFunction{
+		{
+			Name: "isLarge",
+			Parameters: []PadFunctionParameter{
+				{Name: "size", Type: "Int"},
+			},
+			Spec: "$size > 100",
+		},
+	}
+
+	assert.False(t, mockedReviewpadFile.equals(otherReviewpadFile))
+}
+
 func TestEquals_WhenReviewpadFilesHaveDiffNumberOfLabels(t *testing.T) {
 	otherReviewpadFile := &ReviewpadFile{}
 	copier.Copy(otherReviewpadFile, mockedReviewpadFile)
@@ -960,13 +1043,80 @@ func TestEquals_WhenReviewpadFilesHaveDiffGroups(t *testing.T) {
 	assert.False(t, mockedReviewpadFile.equals(otherReviewpadFile))
 }
 
+func TestEquals_WhenReviewpadFilesHaveDiffPipelines(t *testing.T) {
+	otherReviewpadFile := &ReviewpadFile{}
+	copier.Copy(otherReviewpadFile, mockedReviewpadFile)
+
+	otherReviewpadFile.Pipelines = []PadPipeline{
+		{
+			Name: "release",
+			Stages: []PadStage{
+				{
+					Actions: []string{"$merge()"},
+				},
+			},
+		},
+	}
+
+	assert.False(t, mockedReviewpadFile.equals(otherReviewpadFile))
+}
+
+func TestAppendSettings(t *testing.T) {
+	reviewpadFile := &ReviewpadFile{Mode: SILENT_MODE}
+
+	importedReviewpadFile := &ReviewpadFile{
+		Edition:      TEAM_EDITION,
+		Mode:         VERBOSE_MODE,
+		IgnoreErrors: true,
+	}
+
+	reviewpadFile.appendSettings(importedReviewpadFile, "", SILENT_MODE)
+
+	wantReviewpadFile := &ReviewpadFile{
+		Edition: TEAM_EDITION,
+		Mode:    SILENT_MODE,
+	}
+
+	assert.Equal(t, wantReviewpadFile, reviewpadFile)
+}
+
+func TestAppendSettings_WhenIgnoreErrorsIsImported(t *testing.T) {
+	tests := map[string]struct {
+		ownIgnoreErrors      bool
+		importedIgnoreErrors []bool
+		wantIgnoreErrors     bool
+	}{
+		"when the importing file ignores errors": {
+			ownIgnoreErrors:      true,
+			importedIgnoreErrors: []bool{false},
+			wantIgnoreErrors:     true,
+		},
+		"when the importing file does not ignore errors": {
+			importedIgnoreErrors: []bool{true, false},
+			wantIgnoreErrors:     false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reviewpadFile := &ReviewpadFile{IgnoreErrors: test.ownIgnoreErrors}
+
+			for _, ignoreErrors := range test.importedIgnoreErrors {
+				reviewpadFile.appendSettings(&ReviewpadFile{IgnoreErrors: ignoreErrors}, "", "")
+			}
+
+			assert.Equal(t, test.wantIgnoreErrors, reviewpadFile.IgnoreErrors)
+		})
+	}
+}
+
 func TestAppendLabels_WhenReviewpadFileHasNoLabels(t *testing.T) {
 	otherReviewpadFile := &ReviewpadFile{}
 	copier.Copy(otherReviewpadFile, mockedReviewpadFile)
 
 	otherReviewpadFile.Labels = nil
 
-	otherReviewpadFile.appendLabels(mockedReviewpadFile)
+	otherReviewpadFile.appendLabels(mockedReviewpadFile, map[string]bool{})
 
 	wantLabels := map[string]PadLabel{
 		"bug": {
@@ -991,7 +1141,7 @@ func TestAppendLabels_WhenReviewpadFileHasLabels(t *testing.T) {
 		},
 	}
 
-	otherReviewpadFile.appendLabels(mockedReviewpadFile)
+	otherReviewpadFile.appendLabels(mockedReviewpadFile, map[string]bool{})
 
 	wantLabels := map[string]PadLabel{
 		"bug": {
@@ -1015,7 +1165,7 @@ func TestAppendRules_WhenReviewpadFileHasNoRules(t *testing.T) {
 
 	otherReviewpadFile.Rules = nil
 
-	otherReviewpadFile.appendRules(mockedReviewpadFile)
+	otherReviewpadFile.appendRules(mockedReviewpadFile, map[string]bool{})
 
 	wantRules := []PadRule{
 		{
@@ -1042,7 +1192,7 @@ func TestAppendRules_WhenReviewpadFileHasRules(t *testing.T) {
 		},
 	}
 
-	otherReviewpadFile.appendRules(mockedReviewpadFile)
+	otherReviewpadFile.appendRules(mockedReviewpadFile, map[string]bool{})
 
 	wantRules := []PadRule{
 		{
@@ -1062,13 +1212,24 @@ func TestAppendRules_WhenReviewpadFileHasRules(t *testing.T) {
 	assert.Equal(t, wantRules, otherReviewpadFile.Rules)
 }
 
+func TestAppendFunctions(t *testing.T) {
+	otherReviewpadFile := &ReviewpadFile{}
+	copier.Copy(otherReviewpadFile, mockedReviewpadFile)
+
+	otherReviewpadFile.Functions = nil
+
+	otherReviewpadFile.appendFunctions(mockedReviewpadFile, map[string]bool{})
+
+	assert.Equal(t, mockedReviewpadFile.Functions, otherReviewpadFile.Functions)
+}
+
 func TestAppendGroups_WhenReviewpadFileHasNoGroups(t *testing.T) {
 	otherReviewpadFile := &ReviewpadFile{}
 	copier.Copy(otherReviewpadFile, mockedReviewpadFile)
 
 	otherReviewpadFile.Groups = nil
 
-	otherReviewpadFile.appendGroups(mockedReviewpadFile)
+	otherReviewpadFile.appendGroups(mockedReviewpadFile, map[string]bool{})
 
 	wantGroups := []PadGroup{
 		{
@@ -1097,7 +1258,7 @@ func TestAppendGroups_WhenReviewpadFileHasGroups(t *testing.T) {
 		},
 	}
 
-	otherReviewpadFile.appendGroups(mockedReviewpadFile)
+	otherReviewpadFile.appendGroups(mockedReviewpadFile, map[string]bool{})
 
 	wantGroups := []PadGroup{
 		{
@@ -1125,7 +1286,7 @@ func TestAppendWorkflows_WhenReviewpadFileHasNoWorkflows(t *testing.T) {
 
 	otherReviewpadFile.Workflows = nil
 
-	otherReviewpadFile.appendWorkflows(mockedReviewpadFile)
+	otherReviewpadFile.appendWorkflows(mockedReviewpadFile, map[string]bool{})
 
 	wantWorkflows := []PadWorkflow{
 		{
@@ -1168,7 +1329,7 @@ func TestAppendWorkflows_WhenReviewpadFileHasWorkflows(t *testing.T) {
 		},
 	}
 
-	otherReviewpadFile.appendWorkflows(mockedReviewpadFile)
+	otherReviewpadFile.appendWorkflows(mockedReviewpadFile, map[string]bool{})
 
 	wantWorkflows := []PadWorkflow{
 		{
@@ -1204,6 +1365,83 @@ func TestAppendWorkflows_WhenReviewpadFileHasWorkflows(t *testing.T) {
 	assert.Equal(t, wantWorkflows, otherReviewpadFile.Workflows)
 }
 
+func TestAppendRules_WhenRulesHaveTheSameName(t *testing.T) {
+	reviewpadFile := &ReviewpadFile{
+		Rules: []PadRule{
+			{Name: "is-small", Kind: "patch", Spec: "$size() <= 30"},
+			{Name: "is-medium", Kind: "patch", Spec: "$size() <= 100"},
+		},
+	}
+
+	importedReviewpadFile := &ReviewpadFile{
+		Rules: []PadRule{
+			{Name: "is-small", Kind: "patch", Spec: "$size() <= 10"},
+			{Name: "is-medium", Kind: "patch", Spec: "$size() <= 50"},
+		},
+	}
+
+	// The rule is-small is defined by the importing file while is-medium is defined by a previous import.
+	reviewpadFile.appendRules(importedReviewpadFile, map[string]bool{"is-small": true})
+
+	wantRules := []PadRule{
+		{Name: "is-small", Kind: "patch", Spec: "$size() <= 30"},
+		{Name: "is-medium", Kind: "patch", Spec: "$size() <= 50"},
+	}
+
+	assert.Equal(t, wantRules, reviewpadFile.Rules)
+}
+
+func TestAppendWorkflows_WhenWorkflowsHaveTheSameName(t *testing.T) {
+	reviewpadFile := &ReviewpadFile{
+		Workflows: []PadWorkflow{
+			{Name: "label", Actions: []string{`$addLabel("small")`}},
+			{Name: "merge", Actions: []string{"$merge()"}},
+		},
+	}
+
+	importedReviewpadFile := &ReviewpadFile{
+		Workflows: []PadWorkflow{
+			{Name: "label", Actions: []string{`$addLabel("medium")`}},
+			{Name: "comment", Actions: []string{`$comment("hello")`}},
+		},
+	}
+
+	reviewpadFile.appendWorkflows(importedReviewpadFile, map[string]bool{})
+
+	// The overridden workflow keeps its position, since the workflows run in order.
+	wantWorkflows := []PadWorkflow{
+		{Name: "label", Actions: []string{`$addLabel("medium")`}},
+		{Name: "merge", Actions: []string{"$merge()"}},
+		{Name: "comment", Actions: []string{`$comment("hello")`}},
+	}
+
+	assert.Equal(t, wantWorkflows, reviewpadFile.Workflows)
+}
+
+func TestExclude(t *testing.T) {
+	reviewpadFile := &ReviewpadFile{
+		Labels:    map[string]PadLabel{"small": {Color: "294b69"}, "medium": {Color: "a8c3f7"}},
+		Functions: []PadFunction{{Name: "isSmall", Spec: "$size() <= 30"}},
+		Groups:    []PadGroup{{Name: "owners", Spec: `["john"]`}},
+		Rules:     []PadRule{{Name: "is-small", Spec: "$isSmall()"}, {Name: "is-medium", Spec: "$size() <= 100"}},
+		Workflows: []PadWorkflow{{Name: "small"}, {Name: "medium"}},
+		Pipelines: []PadPipeline{{Name: "release"}, {Name: "medium"}},
+	}
+
+	reviewpadFile.exclude([]string{"medium", "is-medium", "owners"})
+
+	wantReviewpadFile := &ReviewpadFile{
+		Labels:    map[string]PadLabel{"small": {Color: "294b69"}},
+		Functions: []PadFunction{{Name: "isSmall", Spec: "$size() <= 30"}},
+		Groups:    []PadGroup{},
+		Rules:     []PadRule{{Name: "is-small", Spec: "$isSmall()"}},
+		Workflows: []PadWorkflow{{Name: "small"}},
+		Pipelines: []PadPipeline{{Name: "release"}},
+	}
+
+	assert.Equal(t, wantReviewpadFile, reviewpadFile)
+}
+
 func TestFindGroup_WhenGroupExists(t *testing.T) {
 	groups := []PadGroup{
 		{
@@ -1311,3 +1549,66 @@ func TestFindRule_WhenRuleDoesNotExists(t *testing.T) {
 	assert.False(t, found)
 	assert.Nil(t, gotRule)
 }
+
+func TestSortFunctions(t *testing.T) {
+	functions := []PadFunction{
+		{Name: "isReady", Spec: `$isSmall($size()) && !$isDraft()`},
+		{Name: "isSmall", Spec: `$isPositive($size) && $size < 10`},
+		{Name: "isPositive", Spec: `$value > 0`},
+	}
+
+	wantFunctions := []PadFunction{
+		{Name: "isPositive", Spec: `$value > 0`},
+		{Name: "isSmall", Spec: `$isPositive($size) && $size < 10`},
+		{Name: "isReady", Spec: `$isSmall($size()) && !$isDraft()`},
+	}
+
+	callGraph := CallGraph{
+		"isReady":    {"isSmall"},
+		"isSmall":    {"isPositive"},
+		"isPositive": {},
+	}
+
+	gotFunctions, err := SortFunctions(functions, callGraph)
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantFunctions, gotFunctions)
+}
+
+func TestSortFunctions_WhenFunctionIsRecursive(t *testing.T) {
+	tests := map[string]struct {
+		functions []PadFunction
+		callGraph CallGraph
+		wantErr   string
+	}{
+		"directly": {
+			functions: []PadFunction{
+				{Name: "countdown", Spec: `$value == 0 || $countdown($value - 1)`},
+			},
+			callGraph: CallGraph{"countdown": {"countdown"}},
+			wantErr:   "function countdown is recursive: countdown -> countdown",
+		},
+		"through other functions": {
+			functions: []PadFunction{
+				{Name: "isReady", Spec: `$isSmall($size())`},
+				{Name: "isSmall", Spec: `$size < 10 && $isValid($size)`},
+				{Name: "isValid", Spec: `$isSmall($size)`},
+			},
+			callGraph: CallGraph{
+				"isReady": {"isSmall"},
+				"isSmall": {"isValid"},
+				"isValid": {"isSmall"},
+			},
+			wantErr: "function isSmall is recursive: isSmall -> isValid -> isSmall",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotFunctions, err := SortFunctions(test.functions, test.callGraph)
+
+			assert.Nil(t, gotFunctions)
+			assert.EqualError(t, err, test.wantErr)
+		})
+	}
+}
@@ -45,7 +45,7 @@ func getAllMatches(pattern string, groups []PadGroup, rules []PadRule, workflows
 	}
 
 	for _, workflow := range workflows {
-		actions := workflow.Actions
+		actions := append(append([]string{}, workflow.Actions...), workflow.ElseActions...)
 		groupFunctionCalls := make([]string, 0)
 		for _, action := range actions {
 			groupFunctionCalls = append(groupFunctionCalls, rePatternFnCall.FindAllString(action, -1)...)
@@ -94,6 +94,50 @@ func lintRules(padRules []PadRule) error {
 	return nil
 }
 
+// Validations:
+// - Every function has a (unique) name
+// - Every function has a spec
+// - Every parameter has a (unique) name and a type
+// The recursive functions are reported by aladino.Lint, which parses the specs to find the calls.
+func lintFunctions(padFunctions []PadFunction) error {
+	functionsName := make([]string, 0)
+
+	for _, function := range padFunctions {
+		if function.Name == "" {
+			return lintError("function %v has invalid name", function)
+		}
+
+		if utils.ElementOf(functionsName, function.Name) {
+			return lintError("function with the name %v already exists", function.Name)
+		}
+
+		if function.Spec == "" {
+			return lintError("function %v has empty spec", function.Name)
+		}
+
+		parametersName := make([]string, 0)
+		for _, parameter := range function.Parameters {
+			if parameter.Name == "" {
+				return lintError("function %v has a parameter with invalid name", function.Name)
+			}
+
+			if utils.ElementOf(parametersName, parameter.Name) {
+				return lintError("function %v has more than one parameter with the name %v", function.Name, parameter.Name)
+			}
+
+			if parameter.Type == "" {
+				return lintError("parameter %v of function %v has no type", parameter.Name, function.Name)
+			}
+
+			parametersName = append(parametersName, parameter.Name)
+		}
+
+		functionsName = append(functionsName, function.Name)
+	}
+
+	return nil
+}
+
 // Validations:
 // - Group has unique name
 func lintGroups(padGroups []PadGroup) error {
@@ -131,6 +175,8 @@ func lintWorkflows(rules []PadRule, padWorkflows []PadWorkflow) error {
 		lintLog("analyzing workflow %v", workflow.Name)
 
 		workflowHasActions := len(workflow.Actions) > 0
+		// The rules of a workflow with else actions are not ignored, since the else actions run when they do not hold.
+		workflowHasElseActions := len(workflow.ElseActions) > 0
 
 		for _, workflowName := range workflowsName {
 			if workflowName == workflow.Name {
@@ -154,12 +200,12 @@ func lintWorkflows(rules []PadRule, padWorkflows []PadWorkflow) error {
 			}
 
 			workflowHasExtraActions = len(rule.ExtraActions) > 0
-			if !workflowHasExtraActions && !workflowHasActions {
+			if !workflowHasExtraActions && !workflowHasActions && !workflowHasElseActions {
 				lintLog("warning: rule %v will be ignored since it has no actions", ruleName)
 			}
 		}
 
-		if !workflowHasActions && !workflowHasExtraActions {
+		if !workflowHasActions && !workflowHasExtraActions && !workflowHasElseActions {
 			lintLog("warning: workflow has no actions")
 		}
 
@@ -246,7 +292,12 @@ func lintGroupsMentions(groups []PadGroup, rules []PadRule, workflows []PadWorkf
 }
 
 func Lint(file *ReviewpadFile) error {
-	err := lintGroups(file.Groups)
+	err := lintFunctions(file.Functions)
+	if err != nil {
+		return err
+	}
+
+	err = lintGroups(file.Groups)
 	if err != nil {
 		return err
 	}
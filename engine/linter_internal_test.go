@@ -46,3 +46,60 @@ func TestGetCallsToRuleBuiltIn(t *testing.T) {
 
 	assert.Equal(t, wantRuleNames, gotRuleNames)
 }
+
+func TestLintFunctions(t *testing.T) {
+	tests := map[string]struct {
+		functions []PadFunction
+		wantErr   string
+	}{
+		"when functions are valid": {
+			functions: []PadFunction{
+				{Name: "isSmall", Parameters: []PadFunctionParameter{{Name: "size", Type: "Int"}}, Spec: `$size < 10`},
+				{Name: "isSmallPatch", Spec: `$isSmall($size())`},
+			},
+		},
+		"when function has no name": {
+			functions: []PadFunction{
+				{Spec: `true`},
+			},
+			wantErr: "[lint] function {  [] true} has invalid name",
+		},
+		"when function name is repeated": {
+			functions: []PadFunction{
+				{Name: "isSmall", Spec: `$size() < 10`},
+				{Name: "isSmall", Spec: `$size() < 20`},
+			},
+			wantErr: "[lint] function with the name isSmall already exists",
+		},
+		"when function has empty spec": {
+			functions: []PadFunction{
+				{Name: "isSmall"},
+			},
+			wantErr: "[lint] function isSmall has empty spec",
+		},
+		"when parameter name is repeated": {
+			functions: []PadFunction{
+				{Name: "isSmall", Parameters: []PadFunctionParameter{{Name: "size", Type: "Int"}, {Name: "size", Type: "Int"}}, Spec: `$size < 10`},
+			},
+			wantErr: "[lint] function isSmall has more than one parameter with the name size",
+		},
+		"when parameter has no type": {
+			functions: []PadFunction{
+				{Name: "isSmall", Parameters: []PadFunctionParameter{{Name: "size"}}, Spec: `$size < 10`},
+			},
+			wantErr: "[lint] parameter size of function isSmall has no type",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := lintFunctions(test.functions)
+
+			if test.wantErr == "" {
+				assert.Nil(t, err)
+			} else {
+				assert.EqualError(t, err, test.wantErr)
+			}
+		})
+	}
+}
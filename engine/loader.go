@@ -5,19 +5,42 @@
 package engine
 
 import (
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
+	gh "github.com/reviewpad/reviewpad/v3/codehost/github"
 	"github.com/reviewpad/reviewpad/v3/handler"
 	"gopkg.in/yaml.v3"
 )
 
+// importTimeout bounds the time to fetch a remote import.
+const importTimeout = 30 * time.Second
+
+var importHttpClient = &http.Client{Timeout: importTimeout}
+
 type LoadEnv struct {
 	Visited map[string]bool
 	Stack   map[string]bool
+	Ctx     context.Context
+	// GithubClient fetches the files imported from repositories.
+	GithubClient *gh.GithubClient
+	// Lock has the sha256 pinned in the lockfile by the source of the remote imports.
+	Lock map[importSource]string
+	// CacheDir is the directory of the cache of the remote imports by their sha256.
+	CacheDir string
+	// Resolved are the locations of the remote imports with the sha256 of their content.
+	Resolved []PadImport
 }
 
 func hash(data []byte) string {
@@ -26,12 +49,31 @@ func hash(data []byte) string {
 	return dHash
 }
 
+// Load loads a reviewpad file whose local imports are relative to the working directory.
 func Load(data []byte) (*ReviewpadFile, error) {
-	file, err := parse(data)
+	return LoadFile(context.Background(), nil, "", data)
+}
+
+// LoadFile loads the reviewpad file at filePath, whose local imports are relative to filePath.
+// The files imported from repositories are fetched with the GitHub client.
+// The remote imports must have the sha256 pinned in the lockfile of the file, if any.
+func LoadFile(ctx context.Context, githubClient *gh.GithubClient, filePath string, data []byte) (*ReviewpadFile, error) {
+	lock, err := readLock(filePath)
 	if err != nil {
 		return nil, err
 	}
 
+	file, _, err := load(ctx, githubClient, filePath, data, lock)
+
+	return file, err
+}
+
+func load(ctx context.Context, githubClient *gh.GithubClient, filePath string, data []byte, lock map[importSource]string) (*ReviewpadFile, *LoadEnv, error) {
+	file, err := parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	dHash := hash(data)
 
 	visited := make(map[string]bool)
@@ -40,21 +82,25 @@ func Load(data []byte) (*ReviewpadFile, error) {
 	stack[dHash] = true
 
 	env := &LoadEnv{
-		Visited: visited,
-		Stack:   stack,
+		Visited:      visited,
+		Stack:        stack,
+		Ctx:          ctx,
+		GithubClient: githubClient,
+		Lock:         lock,
+		CacheDir:     importsCacheDir(),
 	}
 
-	file, err = processImports(file, env)
+	file, err = processImports(file, PadImport{Path: filePath}, env)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	file, err = processInlineRules(file)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return transform(file), nil
+	return transform(file), env, nil
 }
 
 func parse(data []byte) (*ReviewpadFile, error) {
@@ -67,7 +113,47 @@ func parse(data []byte) (*ReviewpadFile, error) {
 	return &file, nil
 }
 
+// Marshal writes a loaded reviewpad file back as YAML, e.g. to print the file resolved from its imports.
+func Marshal(file *ReviewpadFile) ([]byte, error) {
+	resolvedFile := *file
+	resolvedFile.Workflows = make([]PadWorkflow, len(file.Workflows))
+
+	// The rules of the workflows are written in the if field, as their names when they have no extra actions.
+	for i, workflow := range file.Workflows {
+		rawRules := make([]interface{}, 0, len(workflow.Rules))
+		for _, rule := range workflow.Rules {
+			if len(rule.ExtraActions) == 0 {
+				rawRules = append(rawRules, rule.Rule)
+			} else {
+				rawRules = append(rawRules, rule)
+			}
+		}
+
+		workflow.NonNormalizedRules = rawRules
+		resolvedFile.Workflows[i] = workflow
+	}
+
+	var content bytes.Buffer
+	encoder := yaml.NewEncoder(&content)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(resolvedFile); err != nil {
+		return nil, err
+	}
+
+	return content.Bytes(), nil
+}
+
 func transform(file *ReviewpadFile) *ReviewpadFile {
+	var transformedFunctions []PadFunction
+	for _, function := range file.Functions {
+		transformedFunctions = append(transformedFunctions, PadFunction{
+			Name:        function.Name,
+			Description: function.Description,
+			Parameters:  function.Parameters,
+			Spec:        function.Spec,
+		})
+	}
+
 	var transformedRules []PadRule
 	for _, rule := range file.Rules {
 		kind := rule.Kind
@@ -78,7 +164,7 @@ func transform(file *ReviewpadFile) *ReviewpadFile {
 			Name:        rule.Name,
 			Kind:        kind,
 			Description: rule.Description,
-			Spec:        transformAladinoExpression(rule.Spec),
+			Spec:        rule.Spec,
 		})
 	}
 
@@ -86,22 +172,12 @@ func transform(file *ReviewpadFile) *ReviewpadFile {
 	for _, workflow := range file.Workflows {
 		var transformedRules []PadWorkflowRule
 		for _, rule := range workflow.Rules {
-			var transformedExtraActions []string
-			for _, extraAction := range rule.ExtraActions {
-				transformedExtraActions = append(transformedExtraActions, transformAladinoExpression(extraAction))
-			}
-
 			transformedRules = append(transformedRules, PadWorkflowRule{
 				Rule:         rule.Rule,
-				ExtraActions: transformedExtraActions,
+				ExtraActions: rule.ExtraActions,
 			})
 		}
 
-		var transformedActions []string
-		for _, action := range workflow.Actions {
-			transformedActions = append(transformedActions, transformAladinoExpression(action))
-		}
-
 		transformedOn := []handler.TargetEntityKind{handler.PullRequest}
 		if len(workflow.On) > 0 {
 			transformedOn = workflow.On
@@ -112,7 +188,8 @@ func transform(file *ReviewpadFile) *ReviewpadFile {
 			On:          transformedOn,
 			Description: workflow.Description,
 			Rules:       transformedRules,
-			Actions:     transformedActions,
+			Actions:     workflow.Actions,
+			ElseActions: workflow.ElseActions,
 			AlwaysRun:   workflow.AlwaysRun,
 		})
 	}
@@ -125,7 +202,7 @@ func transform(file *ReviewpadFile) *ReviewpadFile {
 		for _, stage := range pipeline.Stages {
 			var transformedActions []string
 			for _, action := range stage.Actions {
-				transformedActions = append(transformedActions, transformAladinoExpression(action))
+				transformedActions = append(transformedActions, action)
 			}
 
 			transformedStages = append(transformedStages, PadStage{
@@ -148,6 +225,7 @@ func transform(file *ReviewpadFile) *ReviewpadFile {
 		Mode:         file.Mode,
 		IgnoreErrors: file.IgnoreErrors,
 		Imports:      file.Imports,
+		Functions:    transformedFunctions,
 		Groups:       file.Groups,
 		Rules:        transformedRules,
 		Labels:       file.Labels,
@@ -156,19 +234,86 @@ func transform(file *ReviewpadFile) *ReviewpadFile {
 	}
 }
 
-func loadImport(reviewpadImport PadImport) (*ReviewpadFile, string, error) {
-	resp, err := http.Get(reviewpadImport.Url)
-	if err != nil {
-		return nil, "", err
+// resolveImport resolves the location of an import of the file loaded from origin.
+// Relative paths are resolved from the importing file, whether it is on disk, in a repository or at an url.
+func resolveImport(origin PadImport, reviewpadImport PadImport) (PadImport, error) {
+	importPath := reviewpadImport.Path
+
+	switch {
+	case reviewpadImport.Url != "":
+		if importPath != "" || reviewpadImport.Repo != "" || reviewpadImport.Ref != "" {
+			return PadImport{}, fmt.Errorf("loader: import of %v cannot have a path, repo or ref", reviewpadImport.Url)
+		}
+
+		return PadImport{Url: reviewpadImport.Url}, nil
+	case importPath == "":
+		return PadImport{}, fmt.Errorf("loader: import has no url or path")
+	case reviewpadImport.Repo != "":
+		return PadImport{Repo: reviewpadImport.Repo, Path: path.Clean(strings.TrimPrefix(importPath, "/")), Ref: reviewpadImport.Ref}, nil
+	case reviewpadImport.Ref != "":
+		return PadImport{}, fmt.Errorf("loader: import of %v has a ref but no repo", importPath)
+	case origin.Repo != "":
+		// A path that starts with / is relative to the root of the repository.
+		if !strings.HasPrefix(importPath, "/") {
+			importPath = path.Join(path.Dir(origin.Path), importPath)
+		}
+
+		return PadImport{Repo: origin.Repo, Path: path.Clean(strings.TrimPrefix(importPath, "/")), Ref: origin.Ref}, nil
+	case origin.Url != "":
+		originUrl, err := url.Parse(origin.Url)
+		if err != nil {
+			return PadImport{}, err
+		}
+
+		importUrl, err := url.Parse(importPath)
+		if err != nil {
+			return PadImport{}, err
+		}
+
+		return PadImport{Url: originUrl.ResolveReference(importUrl).String()}, nil
 	}
 
-	defer resp.Body.Close()
+	if !filepath.IsAbs(importPath) {
+		importPath = filepath.Join(filepath.Dir(origin.Path), importPath)
+	}
+
+	return PadImport{Path: importPath}, nil
+}
+
+func isRemote(reviewpadImport PadImport) bool {
+	return reviewpadImport.Url != "" || reviewpadImport.Repo != ""
+}
+
+func importLocation(reviewpadImport PadImport) string {
+	switch {
+	case reviewpadImport.Url != "":
+		return reviewpadImport.Url
+	case reviewpadImport.Repo != "":
+		return fmt.Sprintf("%v from %v", reviewpadImport.Path, reviewpadImport.Repo)
+	}
+
+	return reviewpadImport.Path
+}
+
+// loadImport loads an import resolved by resolveImport, whose content must have the given sha256 unless it is empty.
+func loadImport(reviewpadImport PadImport, sha256 string, env *LoadEnv) (*ReviewpadFile, string, error) {
+	var content []byte
+	var err error
+
+	if isRemote(reviewpadImport) {
+		content, err = loadRemote(reviewpadImport, sha256, env)
+	} else {
+		content, err = os.ReadFile(reviewpadImport.Path)
+	}
 
-	content, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, "", err
 	}
 
+	if sha256 != "" && hash(content) != sha256 {
+		return nil, "", fmt.Errorf("loader: import of %v has sha256 %v, expected %v", importLocation(reviewpadImport), hash(content), sha256)
+	}
+
 	file, err := parse(content)
 	if err != nil {
 		return nil, "", err
@@ -177,31 +322,139 @@ func loadImport(reviewpadImport PadImport) (*ReviewpadFile, string, error) {
 	return file, hash(content), nil
 }
 
+// loadRemote fetches a remote import, unless the content with the given sha256 is in the cache.
+func loadRemote(reviewpadImport PadImport, sha256 string, env *LoadEnv) ([]byte, error) {
+	if content, ok := readCache(env.CacheDir, sha256); ok {
+		return content, nil
+	}
+
+	var content []byte
+	var err error
+
+	if reviewpadImport.Url != "" {
+		content, err = loadUrl(env.Ctx, reviewpadImport.Url)
+	} else {
+		content, err = loadRepoFile(reviewpadImport, env)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	writeCache(env.CacheDir, content)
+
+	return content, nil
+}
+
+func loadUrl(ctx context.Context, importUrl string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, importUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := importHttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	// The body of an error response is not the content of the import, so it is neither cached nor pinned.
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("loader: import of %v failed with status %v", importUrl, resp.Status)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func loadRepoFile(reviewpadImport PadImport, env *LoadEnv) ([]byte, error) {
+	if env.GithubClient == nil {
+		return nil, fmt.Errorf("loader: import of %v from %v requires a GitHub client", reviewpadImport.Path, reviewpadImport.Repo)
+	}
+
+	owner, repo, ok := strings.Cut(reviewpadImport.Repo, "/")
+	if !ok || owner == "" || repo == "" {
+		return nil, fmt.Errorf("loader: invalid repo %v, expected owner/name", reviewpadImport.Repo)
+	}
+
+	ctx, cancel := context.WithTimeout(env.Ctx, importTimeout)
+	defer cancel()
+
+	return env.GithubClient.GetFileContents(ctx, owner, repo, reviewpadImport.Path, reviewpadImport.Ref)
+}
+
+// resolve records the sha256 of the content of a remote import for its lockfile.
+func (env *LoadEnv) resolve(reviewpadImport PadImport, sha256 string) {
+	for _, resolvedImport := range env.Resolved {
+		if resolvedImport.source() == reviewpadImport.source() && resolvedImport.Sha256 == sha256 {
+			return
+		}
+	}
+
+	env.Resolved = append(env.Resolved, PadImport{
+		Url:    reviewpadImport.Url,
+		Path:   reviewpadImport.Path,
+		Repo:   reviewpadImport.Repo,
+		Ref:    reviewpadImport.Ref,
+		Sha256: sha256,
+	})
+}
+
 // processImports inlines the imports files into the current reviewpad file
+// The relative paths of the imports are resolved from origin, the location of the file.
 // Post-condition: ReviewpadFile without import statements
-func processImports(file *ReviewpadFile, env *LoadEnv) (*ReviewpadFile, error) {
+func processImports(file *ReviewpadFile, origin PadImport, env *LoadEnv) (*ReviewpadFile, error) {
+	own := file.names()
+	ownEdition, ownMode := file.Edition, file.Mode
+
 	for _, reviewpadImport := range file.Imports {
-		iFile, idHash, err := loadImport(reviewpadImport)
+		resolvedImport, err := resolveImport(origin, reviewpadImport)
 		if err != nil {
 			return nil, err
 		}
 
+		sha256 := reviewpadImport.Sha256
+		if sha256 == "" {
+			sha256 = env.Lock[resolvedImport.source()]
+		} else if !sha256Pattern.MatchString(sha256) {
+			return nil, fmt.Errorf("loader: invalid sha256 %v of import of %v", sha256, importLocation(resolvedImport))
+		}
+
+		iFile, idHash, err := loadImport(resolvedImport, sha256, env)
+		if err != nil {
+			return nil, err
+		}
+
+		if isRemote(resolvedImport) {
+			env.resolve(resolvedImport, idHash)
+		}
+
 		// check for cycles
 		if _, ok := env.Stack[idHash]; ok {
 			return nil, fmt.Errorf("loader: cyclic dependency")
 		}
 
 		// optimize visits
-		if _, ok := env.Visited[idHash]; ok {
+		// the same file imported with other variables or exclusions is visited again
+		visit := idHash
+		if len(reviewpadImport.With) > 0 || len(reviewpadImport.Exclude) > 0 {
+			visit = hash([]byte(fmt.Sprint(idHash, reviewpadImport.With, reviewpadImport.Exclude)))
+		}
+
+		if _, ok := env.Visited[visit]; ok {
 			continue
 		}
 
+		if err := substituteVariables(iFile, reviewpadImport.With); err != nil {
+			return nil, fmt.Errorf("loader: import of %v: %v", importLocation(resolvedImport), err)
+		}
+
 		// DFS call inline imports
 		// update the environment
 		env.Stack[idHash] = true
-		env.Visited[idHash] = true
+		env.Visited[visit] = true
 
-		subTreeFile, err := processImports(iFile, env)
+		subTreeFile, err := processImports(iFile, resolvedImport, env)
 		if err != nil {
 			return nil, err
 		}
@@ -209,11 +462,23 @@ func processImports(file *ReviewpadFile, env *LoadEnv) (*ReviewpadFile, error) {
 		// remove from the stack
 		delete(env.Stack, idHash)
 
-		// append labels, rules and workflows
-		file.appendLabels(subTreeFile)
-		file.appendGroups(subTreeFile)
-		file.appendRules(subTreeFile)
-		file.appendWorkflows(subTreeFile)
+		importedNames := subTreeFile.names()
+		for _, name := range reviewpadImport.Exclude {
+			if !importedNames.has(name) {
+				return nil, fmt.Errorf("loader: import of %v: excluded %v is not defined", importLocation(resolvedImport), name)
+			}
+		}
+
+		subTreeFile.exclude(reviewpadImport.Exclude)
+
+		// append settings, labels, functions, groups, rules, workflows and pipelines
+		file.appendSettings(subTreeFile, ownEdition, ownMode)
+		file.appendLabels(subTreeFile, own.labels)
+		file.appendFunctions(subTreeFile, own.functions)
+		file.appendGroups(subTreeFile, own.groups)
+		file.appendRules(subTreeFile, own.rules)
+		file.appendWorkflows(subTreeFile, own.workflows)
+		file.appendPipelines(subTreeFile, own.pipelines)
 	}
 
 	// reset all imports
@@ -231,10 +496,12 @@ func processInlineRules(file *ReviewpadFile) (*ReviewpadFile, error) {
 		Mode:         file.Mode,
 		IgnoreErrors: file.IgnoreErrors,
 		Imports:      file.Imports,
+		Functions:    file.Functions,
 		Groups:       file.Groups,
 		Rules:        file.Rules,
 		Labels:       file.Labels,
 		Workflows:    file.Workflows,
+		Pipelines:    file.Pipelines,
 	}
 
 	for i, workflow := range reviewpadFile.Workflows {
@@ -257,6 +524,7 @@ func processInlineRulesOnWorkflow(workflow PadWorkflow, currentRules []PadRule)
 		AlwaysRun:   workflow.AlwaysRun,
 		Rules:       workflow.Rules,
 		Actions:     workflow.Actions,
+		ElseActions: workflow.ElseActions,
 		On:          workflow.On,
 	}
 	rules := make([]PadRule, 0)
@@ -269,8 +537,7 @@ func processInlineRulesOnWorkflow(workflow PadWorkflow, currentRules []PadRule)
 		case string:
 			rule = decodeRule(r)
 			workflowRule = &PadWorkflowRule{
-				Rule:         rule.Name,
-				ExtraActions: []string{},
+				Rule: rule.Name,
 			}
 		case map[string]interface{}:
 			decodedWorkflowRule, err := decodeWorkflowRule(r)
@@ -5,10 +5,17 @@
 package engine_test
 
 import (
+	"context"
+	"encoding/base64"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/google/go-github/v45/github"
 	"github.com/jarcoal/httpmock"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	gh "github.com/reviewpad/reviewpad/v3/codehost/github"
 	"github.com/reviewpad/reviewpad/v3/engine"
 	"github.com/reviewpad/reviewpad/v3/engine/testutils"
 	"github.com/reviewpad/reviewpad/v3/utils"
@@ -41,6 +48,16 @@ func TestLoad(t *testing.T) {
 			},
 			wantErr: "Get \"https://foo.bar/nonexistent_file\": file doesn't exist",
 		},
+		"when the file imports a url that is not found": {
+			inputReviewpadFilePath: "testdata/loader/reviewpad_with_import_of_nonexistent_file.yml",
+			httpMockResponders: []httpMockResponder{
+				{
+					url:       "https://foo.bar/nonexistent_file",
+					responder: httpmock.NewStringResponder(404, "404: Not Found"),
+				},
+			},
+			wantErr: "loader: import of https://foo.bar/nonexistent_file failed with status 404",
+		},
 		"when the file imports a file that has a parsing error": {
 			inputReviewpadFilePath: "testdata/loader/reviewpad_with_import_file_with_parse_error.yml",
 			httpMockResponders: []httpMockResponder{
@@ -83,14 +100,6 @@ func TestLoad(t *testing.T) {
 			inputReviewpadFilePath: "testdata/loader/reviewpad_with_no_imports.yml",
 			wantReviewpadFilePath:  "testdata/loader/reviewpad_with_no_imports.yml",
 		},
-		"when the file requires action transformation": {
-			inputReviewpadFilePath: "testdata/loader/transform/reviewpad_before_action_transform.yml",
-			wantReviewpadFilePath:  "testdata/loader/transform/reviewpad_after_action_transform.yml",
-		},
-		"when the file requires extra action transformation": {
-			inputReviewpadFilePath: "testdata/loader/transform/reviewpad_before_extra_action_transform.yml",
-			wantReviewpadFilePath:  "testdata/loader/transform/reviewpad_after_extra_action_transform.yml",
-		},
 		"when the file has no on field": {
 			inputReviewpadFilePath: "testdata/loader/transform/reviewpad_before_on_transform.yml",
 			wantReviewpadFilePath:  "testdata/loader/transform/reviewpad_after_on_transform.yml",
@@ -117,6 +126,8 @@ func TestLoad(t *testing.T) {
 		},
 	}
 
+	t.Setenv("REVIEWPAD_CACHE_DIR", t.TempDir())
+
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			if test.httpMockResponders != nil {
@@ -154,6 +165,170 @@ func TestLoad(t *testing.T) {
 	}
 }
 
+func TestLoadFile(t *testing.T) {
+	// repoFiles are the files of the reviewpad/policies repository at the ref v1.
+	repoFiles := map[string]string{
+		"policies/reviewpad_with_imports_chain.yml": "testdata/loader/repo/reviewpad_with_imports_chain.yml",
+		"policies/reviewpad_with_no_imports.yml":    "testdata/loader/reviewpad_with_no_imports.yml",
+		"reviewpad_with_one_import.yml":             "testdata/loader/repo/reviewpad_with_one_import.yml",
+	}
+
+	mockedGithubClient := engine.MockGithubClient(
+		[]mock.MockBackendOption{
+			mock.WithRequestMatchHandler(
+				mock.GetReposContentsByOwnerByRepoByPath,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					filePath, ok := repoFiles[strings.TrimPrefix(r.URL.Path, "/repos/reviewpad/policies/contents/")]
+					if !ok || r.URL.Query().Get("ref") != "v1" {
+						mock.WriteError(w, http.StatusNotFound, "Not Found")
+						return
+					}
+
+					content := base64.StdEncoding.EncodeToString(httpmock.File(filePath).Bytes())
+					w.Write(mock.MustMarshal(github.RepositoryContent{
+						Encoding: github.String("base64"),
+						Content:  github.String(content),
+					}))
+				}),
+			),
+		},
+	)
+
+	tests := map[string]struct {
+		githubClient           *gh.GithubClient
+		inputReviewpadFilePath string
+		wantReviewpadFilePath  string
+		wantErr                string
+	}{
+		"when the file has local import chains": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_imports_chain.yml",
+			wantReviewpadFilePath:  "testdata/loader/reviewpad_appended.yml",
+		},
+		"when the file has cyclic local imports": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_cyclic_dependency_a.yml",
+			wantErr:                "loader: cyclic dependency",
+		},
+		"when the file imports a file of a repository": {
+			githubClient:           mockedGithubClient,
+			inputReviewpadFilePath: "testdata/loader/repo/reviewpad_with_repo_import.yml",
+			wantReviewpadFilePath:  "testdata/loader/reviewpad_appended.yml",
+		},
+		"when the file imports a file of a repository without a github client": {
+			inputReviewpadFilePath: "testdata/loader/repo/reviewpad_with_repo_import.yml",
+			wantErr:                "loader: import of policies/reviewpad_with_imports_chain.yml from reviewpad/policies requires a GitHub client",
+		},
+		"when the file imports a file with a ref but no repo": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_ref_import_without_repo.yml",
+			wantErr:                "loader: import of reviewpad_with_no_imports.yml has a ref but no repo",
+		},
+		"when the file imports a file with its sha256": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_pinned_imports_chain.yml",
+			wantReviewpadFilePath:  "testdata/loader/reviewpad_appended.yml",
+		},
+		"when the file imports a file with another sha256": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_pinned_import_of_changed_file.yml",
+			wantErr:                "loader: import of testdata/loader/reviewpad_with_no_imports.yml has sha256 " + noImportsSha256 + ", expected 0000000000000000000000000000000000000000000000000000000000000000",
+		},
+		"when the file imports a file with an invalid sha256": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_invalid_sha256_import.yml",
+			wantErr:                "loader: invalid sha256 abc of import of testdata/loader/reviewpad_with_no_imports.yml",
+		},
+		"when the file imports a file with variables and exclusions": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_parameterized_import.yml",
+			wantReviewpadFilePath:  "testdata/loader/local/reviewpad_with_parameterized_import_after_loading.yml",
+		},
+		"when the file imports a file twice with other variables": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_overridden_imports.yml",
+			wantReviewpadFilePath:  "testdata/loader/local/reviewpad_with_overridden_imports_after_loading.yml",
+		},
+		"when the file imports a file without its variables": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_import_without_variables.yml",
+			wantErr:                "loader: import of testdata/loader/local/shared/reviewpad_with_variables.yml: variable owners is not set",
+		},
+		"when the file excludes an undefined rule of an import": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_import_excluding_undefined.yml",
+			wantErr:                "loader: import of testdata/loader/local/shared/reviewpad_with_variables.yml: excluded is-large is not defined",
+		},
+		"when the file imports a file with pipelines and settings": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_pipelines_import.yml",
+			wantReviewpadFilePath:  "testdata/loader/local/reviewpad_with_pipelines_import_after_loading.yml",
+		},
+		"when the file and its imports have unnamed pipelines": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_unnamed_pipelines_imports.yml",
+			wantReviewpadFilePath:  "testdata/loader/local/reviewpad_with_unnamed_pipelines_imports_after_loading.yml",
+		},
+		"when the file that imports a file ignoring errors does not ignore errors": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_pipelines_import_not_ignoring_errors.yml",
+			wantReviewpadFilePath:  "testdata/loader/local/reviewpad_with_pipelines_import_not_ignoring_errors_after_loading.yml",
+		},
+		"when the file imports a file with an url and a path": {
+			inputReviewpadFilePath: "testdata/loader/local/reviewpad_with_url_and_path_import.yml",
+			wantErr:                "loader: import of https://foo.bar/reviewpad_with_no_imports.yml cannot have a path, repo or ref",
+		},
+	}
+
+	t.Setenv("REVIEWPAD_CACHE_DIR", t.TempDir())
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var wantReviewpadFile *engine.ReviewpadFile
+			if test.wantReviewpadFilePath != "" {
+				wantReviewpadFileData, err := utils.LoadFile(test.wantReviewpadFilePath)
+				if err != nil {
+					assert.FailNow(t, "Error reading reviewpad file: %v", err)
+				}
+
+				wantReviewpadFile, err = testutils.ParseReviewpadFile(wantReviewpadFileData)
+				if err != nil {
+					assert.FailNow(t, "Error parsing reviewpad file: %v", err)
+				}
+			}
+
+			reviewpadFileData, err := utils.LoadFile(test.inputReviewpadFilePath)
+			if err != nil {
+				assert.FailNow(t, "Error reading reviewpad file: %v", err)
+			}
+
+			gotReviewpadFile, gotErr := engine.LoadFile(context.Background(), test.githubClient, test.inputReviewpadFilePath, reviewpadFileData)
+
+			if gotErr != nil && gotErr.Error() != test.wantErr {
+				assert.FailNow(t, "LoadFile() error = %v, wantErr %v", gotErr, test.wantErr)
+			}
+			assert.Equal(t, wantReviewpadFile, gotReviewpadFile)
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	reviewpadFilePaths := []string{
+		"testdata/loader/reviewpad_appended.yml",
+		"testdata/loader/process/reviewpad_with_inline_rules_with_extra_actions.yml",
+		"testdata/loader/local/reviewpad_with_pipelines_import_after_loading.yml",
+	}
+
+	for _, reviewpadFilePath := range reviewpadFilePaths {
+		t.Run(reviewpadFilePath, func(t *testing.T) {
+			reviewpadFileData, err := utils.LoadFile(reviewpadFilePath)
+			if err != nil {
+				assert.FailNow(t, "Error reading reviewpad file: %v", err)
+			}
+
+			reviewpadFile, err := engine.Load(reviewpadFileData)
+			if err != nil {
+				assert.FailNow(t, "Error loading reviewpad file: %v", err)
+			}
+
+			marshaledData, err := engine.Marshal(reviewpadFile)
+			assert.Nil(t, err)
+
+			// Loading the marshaled file gives the same file.
+			gotReviewpadFile, err := engine.Load(marshaledData)
+			assert.Nil(t, err)
+			assert.Equal(t, reviewpadFile, gotReviewpadFile)
+		})
+	}
+}
+
 func registerHttpResponders(httpMockResponders []httpMockResponder) {
 	for _, httpMockResponder := range httpMockResponders {
 		httpmock.RegisterResponder("GET", httpMockResponder.url, httpMockResponder.responder)
@@ -0,0 +1,204 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package engine
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/reviewpad/reviewpad/v3/handler"
+)
+
+// schemaDescriptions describes each field of the reviewpad file by the name of its type and its key, e.g. PadRule.spec.
+var schemaDescriptions = map[string]string{
+	"ReviewpadFile.api-version":   "Version of the reviewpad file format, e.g. " + API_VERSION + ".",
+	"ReviewpadFile.edition":       "Edition of reviewpad the file is written for.",
+	"ReviewpadFile.mode":          "Whether reviewpad comments on the pull request with a report of its run (verbose) or only with the messages of the built-ins (silent).",
+	"ReviewpadFile.ignore-errors": "Whether the errors of the run are ignored instead of failing the run. It is never imported.",
+	"ReviewpadFile.imports":       "Reviewpad files whose labels, functions, groups, rules, workflows and pipelines are imported, as well as their edition and mode unless the file has its own.",
+	"ReviewpadFile.functions":     "Functions that can be called in the specs, e.g. $isSmall(100).",
+	"ReviewpadFile.groups":        "Groups of users, e.g. the owners of the repository.",
+	"ReviewpadFile.rules":         "Conditions on the pull request or issue that the workflows check.",
+	"ReviewpadFile.labels":        "Labels created in the repository by their key.",
+	"ReviewpadFile.workflows":     "Actions run when the rules of the workflow hold.",
+	"ReviewpadFile.pipelines":     "Stages of actions run one after the other as their conditions are met.",
+
+	"PadImport.url":     "Url of the reviewpad file to import.",
+	"PadImport.path":    "Path of the reviewpad file to import, relative to the importing file or to the root of the repository when repo is set.",
+	"PadImport.repo":    "Repository of the reviewpad file to import, e.g. reviewpad/reviewpad.",
+	"PadImport.ref":     "Branch, tag or commit of the repository to import the reviewpad file from, the default branch when empty.",
+	"PadImport.sha256":  "Hex encoded sha256 the content of the imported reviewpad file must have.",
+	"PadImport.with":    "Values of the variables of the imported reviewpad file, which are written as ${{ name }} in its specs.",
+	"PadImport.exclude": "Names of the labels, functions, groups, rules, workflows and pipelines of the imported reviewpad file that are not imported.",
+
+	"PadFunction.name":        "Name of the function, which is called as $name(...).",
+	"PadFunction.description": "Description of the function.",
+	"PadFunction.parameters":  "Parameters of the function.",
+	"PadFunction.spec":        "Aladino expression the function evaluates.",
+
+	"PadFunctionParameter.name": "Name of the parameter, which is used as $name in the spec of the function.",
+	"PadFunctionParameter.type": "Aladino type of the parameter, e.g. Int, String or []String.",
+
+	"PadGroup.name":        "Name of the group, which is used as $group(\"name\").",
+	"PadGroup.description": "Description of the group.",
+	"PadGroup.kind":        "Kind of the members of the group.",
+	"PadGroup.type":        "Whether the members of the group are listed (static) or filtered from the members of the organization (filter).",
+	"PadGroup.spec":        "Aladino expression with the members of a static group, e.g. [\"john\", \"jane\"].",
+	"PadGroup.param":       "Name of the variable bound to each candidate member in the where condition of a filter group.",
+	"PadGroup.where":       "Aladino condition the members of a filter group meet.",
+
+	"PadRule.name":        "Name of the rule, which is used in the workflows and as $rule(\"name\").",
+	"PadRule.kind":        "Kind of the rule.",
+	"PadRule.description": "Description of the rule.",
+	"PadRule.spec":        "Aladino condition of the rule.",
+
+	"PadLabel.name":        "Name of the label in the repository, the key of the label by default.",
+	"PadLabel.color":       "Hexadecimal color code of the label without the leading #, e.g. 294b69.",
+	"PadLabel.description": "Description of the label.",
+
+	"PadWorkflow.name":        "Name of the workflow.",
+	"PadWorkflow.on":          "Kinds of entities the workflow runs on.",
+	"PadWorkflow.description": "Description of the workflow.",
+	"PadWorkflow.always-run":  "Whether the workflow runs even when a previous workflow ran.",
+	"PadWorkflow.then":        "Actions run when any rule of the workflow holds.",
+	"PadWorkflow.else":        "Actions run when the workflow runs and no rule of the workflow holds, which do not prevent the following workflows from running.",
+	"PadWorkflow.if":          "Rules of the workflow, either the names of rules, inline Aladino conditions or rules with extra actions.",
+
+	"PadWorkflowRule.rule":          "Name of a rule or an inline Aladino condition.",
+	"PadWorkflowRule.extra-actions": "Actions run when the rule holds, besides the actions of the workflow.",
+
+	"PadPipeline.name":        "Name of the pipeline.",
+	"PadPipeline.description": "Description of the pipeline.",
+	"PadPipeline.trigger":     "Aladino condition that starts the pipeline, the pipeline always runs when it is empty.",
+	"PadPipeline.stages":      "Stages of the pipeline, the actions of the first stage whose until condition does not hold are run.",
+
+	"PadStage.actions": "Actions run in the stage.",
+	"PadStage.until":   "Aladino condition that ends the stage.",
+}
+
+// schemaEnums are the values allowed for the fields of the reviewpad file, or for their items when the fields are arrays.
+var schemaEnums = map[string][]interface{}{
+	"ReviewpadFile.edition": {PROFESSIONAL_EDITION, TEAM_EDITION},
+	"ReviewpadFile.mode":    {SILENT_MODE, VERBOSE_MODE},
+	// The reviewpad files use developers as the kind of groups, which is not GroupKindDeveloper.
+	"PadGroup.kind":  {"developers"},
+	"PadGroup.type":  {string(GroupTypeStatic), string(GroupTypeFilter)},
+	"PadRule.kind":   {kinds[0], kinds[1]},
+	"PadWorkflow.on": {string(handler.PullRequest), string(handler.Issue)},
+}
+
+// schemaSpecs are the fields with Aladino specs that can be written as YAML scalars other than strings,
+// e.g. spec: true, since the scalars are decoded as strings.
+var schemaSpecs = map[string]bool{
+	"PadFunction.spec":    true,
+	"PadRule.spec":        true,
+	"PadGroup.spec":       true,
+	"PadGroup.where":      true,
+	"PadPipeline.trigger": true,
+	"PadStage.until":      true,
+}
+
+// Schema builds the JSON schema of the reviewpad file from the types of its fields,
+// e.g. for editors to validate reviewpad files.
+func Schema() map[string]interface{} {
+	schema := structSchema(reflect.TypeOf(ReviewpadFile{}))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["$id"] = "https://" + API_VERSION + "/schema.json"
+	schema["title"] = "Reviewpad file"
+
+	return schema
+}
+
+func structSchema(structType reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+
+		path := structType.Name() + "." + key
+
+		property := fieldSchema(path, field.Type)
+		property["description"] = schemaDescriptions[path]
+
+		if enum, ok := schemaEnums[path]; ok {
+			if items, isArray := property["items"].(map[string]interface{}); isArray {
+				items["enum"] = enum
+			} else {
+				property["enum"] = enum
+			}
+		}
+
+		properties[key] = property
+	}
+
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func fieldSchema(path string, fieldType reflect.Type) map[string]interface{} {
+	switch path {
+	case "ReviewpadFile.api-version":
+		return map[string]interface{}{
+			"type":     "string",
+			"pattern":  `^reviewpad\.com/v`,
+			"examples": []interface{}{API_VERSION},
+		}
+	case "PadImport.sha256":
+		return map[string]interface{}{
+			"type":    "string",
+			"pattern": sha256Pattern.String(),
+		}
+	case "PadImport.with":
+		// The values are substituted in the specs, like the specs they can be other scalars than strings.
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": map[string]interface{}{"type": []interface{}{"string", "boolean", "number"}},
+		}
+	case "PadWorkflow.if":
+		// The rules of a workflow are normalized by the loader, see processInlineRules.
+		return map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"anyOf": []interface{}{
+					map[string]interface{}{"type": "string"},
+					structSchema(reflect.TypeOf(PadWorkflowRule{})),
+				},
+			},
+		}
+	}
+
+	if schemaSpecs[path] {
+		return map[string]interface{}{"type": []interface{}{"string", "boolean", "number"}}
+	}
+
+	return typeSchema(fieldType)
+}
+
+func typeSchema(ty reflect.Type) map[string]interface{} {
+	switch ty.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": typeSchema(ty.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(ty.Elem())}
+	case reflect.Struct:
+		return structSchema(ty)
+	}
+
+	// Any value is allowed.
+	return map[string]interface{}{}
+}
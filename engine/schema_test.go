@@ -0,0 +1,198 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package engine_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// assertHasDescriptions checks that every property of the schema has a description.
+func assertHasDescriptions(t *testing.T, schema map[string]interface{}, path string) {
+	if properties, ok := schema["properties"].(map[string]interface{}); ok {
+		for key, property := range properties {
+			propertyPath := path + "." + key
+			assert.NotEmpty(t, property.(map[string]interface{})["description"], "%v has no description", propertyPath)
+			assertHasDescriptions(t, property.(map[string]interface{}), propertyPath)
+		}
+	}
+
+	for _, key := range []string{"items", "additionalProperties"} {
+		if subSchema, ok := schema[key].(map[string]interface{}); ok {
+			assertHasDescriptions(t, subSchema, path+"."+key)
+		}
+	}
+
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		for _, subSchema := range anyOf {
+			assertHasDescriptions(t, subSchema.(map[string]interface{}), path)
+		}
+	}
+}
+
+// matchesSchema checks the value against the types, properties and enums of the schema.
+func matchesSchema(schema map[string]interface{}, value interface{}) error {
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		for _, subSchema := range anyOf {
+			if matchesSchema(subSchema.(map[string]interface{}), value) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%v does not match any schema", value)
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		if !assert.ObjectsAreEqual(true, contains(enum, value)) {
+			return fmt.Errorf("%v is not one of %v", value, enum)
+		}
+	}
+
+	schemaType := schema["type"]
+	if types, ok := schemaType.([]interface{}); ok {
+		// The types of the fields with several types are scalars.
+		for _, ty := range types {
+			if matchesSchema(map[string]interface{}{"type": ty}, value) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%v is not one of %v", value, types)
+	}
+
+	switch schemaType {
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%v is not a string", value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%v is not a boolean", value)
+		}
+	case "number":
+		if _, ok := value.(int); !ok {
+			return fmt.Errorf("%v is not a number", value)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not an array", value)
+		}
+
+		for i, item := range items {
+			if err := matchesSchema(schema["items"].(map[string]interface{}), item); err != nil {
+				return fmt.Errorf("[%v]: %v", i, err)
+			}
+		}
+	case "object":
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%v is not an object", value)
+		}
+
+		properties, _ := schema["properties"].(map[string]interface{})
+		for key, field := range fields {
+			fieldSchema, isProperty := properties[key].(map[string]interface{})
+			if !isProperty {
+				fieldSchema, isProperty = schema["additionalProperties"].(map[string]interface{})
+			}
+
+			if !isProperty {
+				return fmt.Errorf("%v is not a property", key)
+			}
+
+			if err := matchesSchema(fieldSchema, field); err != nil {
+				return fmt.Errorf("%v: %v", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func contains(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestSchema(t *testing.T) {
+	schema := engine.Schema()
+
+	properties := schema["properties"].(map[string]interface{})
+	workflowProperties := properties["workflows"].(map[string]interface{})["items"].(map[string]interface{})["properties"].(map[string]interface{})
+
+	assert.Equal(t, "https://reviewpad.com/v3.x/schema.json", schema["$id"])
+	assert.Equal(t, []interface{}{"silent", "verbose"}, properties["mode"].(map[string]interface{})["enum"])
+	assert.Equal(t, []interface{}{"professional", "team"}, properties["edition"].(map[string]interface{})["enum"])
+	assert.Equal(t, []interface{}{"pull_request", "issue"}, workflowProperties["on"].(map[string]interface{})["items"].(map[string]interface{})["enum"])
+	assert.NotContains(t, workflowProperties, "Rules")
+
+	assertHasDescriptions(t, schema, "reviewpad")
+}
+
+func TestSchema_WhenFileIsValid(t *testing.T) {
+	files, err := filepath.Glob("testdata/exec/*.yml")
+	if err != nil {
+		assert.FailNow(t, "glob failed", err)
+	}
+
+	files = append(files, "../reviewpad.yml")
+
+	for _, file := range files {
+		t.Run(file, func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				assert.FailNow(t, "read failed", err)
+			}
+
+			var value interface{}
+			if err := yaml.Unmarshal(data, &value); err != nil {
+				assert.FailNow(t, "yaml unmarshal failed", err)
+			}
+
+			assert.Nil(t, matchesSchema(engine.Schema(), value))
+		})
+	}
+}
+
+func TestSchema_WhenFileIsInvalid(t *testing.T) {
+	tests := map[string]struct {
+		file    string
+		wantErr string
+	}{
+		"unknown mode": {
+			file:    "mode: loud",
+			wantErr: "mode: loud is not one of [silent verbose]",
+		},
+		"unknown workflow field": {
+			file:    "workflows:\n  - name: review\n    otherwise: []",
+			wantErr: "workflows: [0]: otherwise is not a property",
+		},
+		"unknown entity kind": {
+			file:    "workflows:\n  - name: review\n    on: [push]",
+			wantErr: "workflows: [0]: on: [0]: push is not one of [pull_request issue]",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var value interface{}
+			if err := yaml.Unmarshal([]byte(test.file), &value); err != nil {
+				assert.FailNow(t, "yaml unmarshal failed", err)
+			}
+
+			assert.EqualError(t, matchesSchema(engine.Schema(), value), test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package reviewpad
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	"gopkg.in/yaml.v3"
+)
+
+// formatter collects the specs of a reviewpad file that are not in canonical form.
+type formatter struct {
+	edits []formatEdit
+}
+
+type formatEdit struct {
+	node *yaml.Node
+	spec string
+}
+
+// Format rewrites the Aladino expressions of a reviewpad file in canonical form.
+// Only the expressions are rewritten so the rest of the file, including comments and blank lines, is kept as is.
+func Format(data []byte) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	if len(document.Content) == 0 {
+		return data, nil
+	}
+
+	file := document.Content[0]
+	if file.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("format: reviewpad file is not a mapping")
+	}
+
+	f := &formatter{}
+
+	ruleNames := make(map[string]bool)
+	for _, rule := range sequenceItems(mappingValue(file, "rules")) {
+		if name := mappingValue(rule, "name"); name != nil {
+			ruleNames[name.Value] = true
+		}
+	}
+
+	for i, function := range sequenceItems(mappingValue(file, "functions")) {
+		if err := f.formatSpec(mappingValue(function, "spec"), fmt.Sprintf("functions[%v].spec", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, group := range sequenceItems(mappingValue(file, "groups")) {
+		if err := f.formatSpec(mappingValue(group, "spec"), fmt.Sprintf("groups[%v].spec", i)); err != nil {
+			return nil, err
+		}
+
+		if err := f.formatSpec(mappingValue(group, "where"), fmt.Sprintf("groups[%v].where", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, rule := range sequenceItems(mappingValue(file, "rules")) {
+		if err := f.formatSpec(mappingValue(rule, "spec"), fmt.Sprintf("rules[%v].spec", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, workflow := range sequenceItems(mappingValue(file, "workflows")) {
+		for j, rule := range sequenceItems(mappingValue(workflow, "if")) {
+			if rule.Kind == yaml.ScalarNode {
+				f.formatInlineRule(rule, ruleNames)
+				continue
+			}
+
+			f.formatInlineRule(mappingValue(rule, "rule"), ruleNames)
+
+			for k, action := range sequenceItems(mappingValue(rule, "extra-actions")) {
+				if err := f.formatSpec(action, fmt.Sprintf("workflows[%v].if[%v].extra-actions[%v]", i, j, k)); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		for j, action := range sequenceItems(mappingValue(workflow, "then")) {
+			if err := f.formatSpec(action, fmt.Sprintf("workflows[%v].then[%v]", i, j)); err != nil {
+				return nil, err
+			}
+		}
+
+		for j, action := range sequenceItems(mappingValue(workflow, "else")) {
+			if err := f.formatSpec(action, fmt.Sprintf("workflows[%v].else[%v]", i, j)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i, pipeline := range sequenceItems(mappingValue(file, "pipelines")) {
+		if err := f.formatSpec(mappingValue(pipeline, "trigger"), fmt.Sprintf("pipelines[%v].trigger", i)); err != nil {
+			return nil, err
+		}
+
+		for j, stage := range sequenceItems(mappingValue(pipeline, "stages")) {
+			for k, action := range sequenceItems(mappingValue(stage, "actions")) {
+				if err := f.formatSpec(action, fmt.Sprintf("pipelines[%v].stages[%v].actions[%v]", i, j, k)); err != nil {
+					return nil, err
+				}
+			}
+
+			if err := f.formatSpec(mappingValue(stage, "until"), fmt.Sprintf("pipelines[%v].stages[%v].until", i, j)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return f.apply(data)
+}
+
+// formatSpec rewrites the Aladino expression of a scalar node in canonical form.
+func (f *formatter) formatSpec(node *yaml.Node, path string) error {
+	if node == nil || node.Kind != yaml.ScalarNode || node.Value == "" {
+		return nil
+	}
+
+	expr, err := aladino.Parse(node.Value)
+	if err != nil {
+		return fmt.Errorf("%v: %w", path, err)
+	}
+
+	if spec := aladino.Print(expr); spec != strings.TrimSpace(node.Value) {
+		f.edits = append(f.edits, formatEdit{node, spec})
+	}
+
+	return nil
+}
+
+// formatInlineRule rewrites a rule of a workflow when it is an inline rule, i.e. an Aladino expression.
+// References to rules, e.g. is-small, are left as they are.
+func (f *formatter) formatInlineRule(node *yaml.Node, ruleNames map[string]bool) {
+	if node == nil || node.Kind != yaml.ScalarNode || ruleNames[node.Value] {
+		return
+	}
+
+	expr, err := aladino.Parse(node.Value)
+	if err != nil {
+		// The rule may be declared in an imported file.
+		return
+	}
+
+	if spec := aladino.Print(expr); spec != strings.TrimSpace(node.Value) {
+		f.edits = append(f.edits, formatEdit{node, spec})
+	}
+}
+
+// apply replaces the source of each edited node by its spec in canonical form.
+func (f *formatter) apply(data []byte) ([]byte, error) {
+	source := string(data)
+	lineOffsets := []int{0}
+	for i, c := range source {
+		if c == '\n' {
+			lineOffsets = append(lineOffsets, i+1)
+		}
+	}
+
+	type replacement struct {
+		start int
+		end   int
+		text  string
+	}
+
+	replacements := make([]replacement, 0, len(f.edits))
+	for _, edit := range f.edits {
+		lineStart := lineOffsets[edit.node.Line-1]
+		start := lineStart
+		// The column of a node is in characters and not in bytes.
+		for column := 1; column < edit.node.Column; column++ {
+			_, size := utf8.DecodeRuneInString(source[start:])
+			start += size
+		}
+
+		var end int
+		var text string
+		switch edit.node.Style {
+		case yaml.LiteralStyle, yaml.FoldedStyle:
+			start, end = blockScalarContent(source, start)
+			if start == end {
+				continue
+			}
+
+			indent := len(source[start:]) - len(strings.TrimLeft(source[start:], " "))
+			text = strings.Repeat(" ", indent) + edit.spec
+		default:
+			end = flowScalarEnd(source, start, edit.node)
+			if end < 0 {
+				continue
+			}
+
+			encodedSpec, err := yaml.Marshal(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: edit.spec, Style: edit.node.Style})
+			if err != nil {
+				return nil, err
+			}
+			text = strings.TrimSuffix(string(encodedSpec), "\n")
+		}
+
+		replacements = append(replacements, replacement{start, end, text})
+	}
+
+	// The source is edited from the end so that the offsets of the remaining replacements are still valid.
+	sort.Slice(replacements, func(i, j int) bool {
+		return replacements[i].start > replacements[j].start
+	})
+
+	for _, r := range replacements {
+		source = source[:r.start] + r.text + source[r.end:]
+	}
+
+	return []byte(source), nil
+}
+
+// flowScalarEnd returns the offset where the source of a plain or quoted scalar that starts at the given offset ends.
+func flowScalarEnd(source string, start int, node *yaml.Node) int {
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		for i := start + 1; i < len(source); i++ {
+			switch source[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1
+			}
+		}
+	case yaml.SingleQuotedStyle:
+		for i := start + 1; i < len(source); i++ {
+			if source[i] != '\'' {
+				continue
+			}
+
+			if i+1 < len(source) && source[i+1] == '\'' {
+				i++
+				continue
+			}
+
+			return i + 1
+		}
+	default:
+		// Plain scalars are formatted only when they fit in a single line.
+		if strings.HasPrefix(source[start:], node.Value) {
+			return start + len(node.Value)
+		}
+	}
+
+	return -1
+}
+
+// blockScalarContent returns the offsets where the content of a literal or folded scalar starts and ends.
+// The header of the scalar, e.g. |- or >, starts at the given offset.
+func blockScalarContent(source string, start int) (int, int) {
+	headerEnd := strings.IndexByte(source[start:], '\n')
+	if headerEnd < 0 {
+		return start, start
+	}
+
+	contentStart, contentEnd := -1, -1
+	indent := 0
+	for offset := start + headerEnd + 1; offset < len(source); {
+		line := source[offset:]
+		if lineEnd := strings.IndexByte(line, '\n'); lineEnd >= 0 {
+			line = line[:lineEnd]
+		}
+
+		if strings.TrimSpace(line) != "" {
+			lineIndent := len(line) - len(strings.TrimLeft(line, " "))
+			if contentStart < 0 {
+				contentStart = offset
+				indent = lineIndent
+			}
+
+			// The content ends at the first line that is less indented than the first one.
+			if lineIndent < indent {
+				break
+			}
+
+			contentEnd = offset + len(line)
+		}
+
+		offset += len(line) + 1
+	}
+
+	if contentStart < 0 {
+		return start, start
+	}
+
+	return contentStart, contentEnd
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return node.Content
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package reviewpad_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	input := `api-version: reviewpad.com/v3.x
+
+# Groups of the project
+groups:
+  - name: owners
+    spec: '["john","jane"]'
+
+rules:
+  - name: is-small
+    # The size is in lines of code
+    spec: $size()<30
+
+  - name: is-ready
+    spec: "(!$isDraft()) && $description() != \"\""
+
+  - name: is-owner
+    spec: |-
+      $author()   in   $group("owners")
+
+workflows:
+  - name: label
+    if:
+      - is-small
+      - rule: $size( ) > 30
+        extra-actions:
+          - '$addLabel( "large" )'
+    then:
+      - $addLabel("small")   # already formatted
+    else:
+      - $removeLabel( "small" )
+
+pipelines:
+  - name: release
+    trigger: $base()=="main"
+    stages:
+      - actions:
+          - $comment("ciao")
+        until: $isDraft( ) == false
+`
+
+	wantOutput := `api-version: reviewpad.com/v3.x
+
+# Groups of the project
+groups:
+  - name: owners
+    spec: '["john", "jane"]'
+
+rules:
+  - name: is-small
+    # The size is in lines of code
+    spec: $size() < 30
+
+  - name: is-ready
+    spec: "!$isDraft() && $description() != \"\""
+
+  - name: is-owner
+    spec: |-
+      $author() in $group("owners")
+
+workflows:
+  - name: label
+    if:
+      - is-small
+      - rule: $size() > 30
+        extra-actions:
+          - '$addLabel("large")'
+    then:
+      - $addLabel("small")   # already formatted
+    else:
+      - $removeLabel("small")
+
+pipelines:
+  - name: release
+    trigger: $base() == "main"
+    stages:
+      - actions:
+          - $comment("ciao")
+        until: $isDraft() == false
+`
+
+	gotOutput, err := reviewpad.Format([]byte(input))
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantOutput, string(gotOutput))
+}
+
+func TestFormat_WhenFileIsFormatted(t *testing.T) {
+	input := `rules:
+  - name: is-small
+    spec: $size() < 30 # small
+`
+
+	gotOutput, err := reviewpad.Format([]byte(input))
+
+	assert.Nil(t, err)
+	assert.Equal(t, input, string(gotOutput))
+}
+
+func TestFormat_WhenSpecHasParseError(t *testing.T) {
+	input := `rules:
+  - name: is-small
+    spec: $size() < 30
+  - name: is-broken
+    spec: $size( <
+`
+
+	gotOutput, err := reviewpad.Format([]byte(input))
+
+	assert.Nil(t, gotOutput)
+	assert.ErrorContains(t, err, "rules[1].spec: parse error")
+}
+
+func TestFormat_WhenFileIsNotAMapping(t *testing.T) {
+	gotOutput, err := reviewpad.Format([]byte("- $size() < 30\n"))
+
+	assert.Nil(t, gotOutput)
+	assert.EqualError(t, err, "format: reviewpad file is not a mapping")
+}
@@ -16,6 +16,13 @@ type BuiltInFunction struct {
 	Type           Type
 	Code           func(e Env, args []Value) (Value, error)
 	SupportedKinds []handler.TargetEntityKind
+	// Pure built-ins return the same value when called with the same arguments during a run,
+	// so the values of their calls are cached in the memo of the environment.
+	// Built-ins whose values can change during a run, e.g. because of the actions of the run, are not pure.
+	Pure bool
+	// RegexParams are the positions of the parameters that are regexes.
+	// The regexes given as string literals are compiled by Lint.
+	RegexParams []int
 }
 
 type BuiltInAction struct {
@@ -48,3 +55,18 @@ func MergeAladinoBuiltIns(builtInsList ...*BuiltIns) *BuiltIns {
 
 	return mergedBuiltIns
 }
+
+// withDefaultArgs appends to args the default values of the optional parameters that were omitted.
+func withDefaultArgs(ty Type, args []Value) []Value {
+	fnTy, ok := ty.(*FunctionType)
+	if !ok || len(args) >= len(fnTy.paramTypes) {
+		return args
+	}
+
+	firstOptional := len(fnTy.paramTypes) - len(fnTy.defaultValues)
+	if len(args) < firstOptional {
+		return args
+	}
+
+	return append(args, fnTy.defaultValues[len(args)-firstOptional:]...)
+}
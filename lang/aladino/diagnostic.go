@@ -0,0 +1,122 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Span is the range of bytes [Start, End) of the source covered by a token or an expression.
+type Span struct {
+	Start int
+	End   int
+}
+
+// Diagnostic is an error located in the source of an Aladino expression.
+// Its message is followed by the source line where the error happened, with the failing part underlined.
+type Diagnostic struct {
+	Message string
+	Source  string
+	Span    Span
+}
+
+func (d *Diagnostic) Error() string {
+	return fmt.Sprintf("%v\n%v", d.Message, d.Snippet())
+}
+
+// Snippet renders the line of the source where the diagnostic starts with a caret under each character of its span.
+// For example:
+//
+//	--> line 1, column 13
+//	  |
+//	1 | $startsWith($title(), 1)
+//	  | ^^^^^^^^^^^^^^^^^^^^^^^^
+func (d *Diagnostic) Snippet() string {
+	start := clamp(d.Span.Start, 0, len(d.Source))
+	end := clamp(d.Span.End, start, len(d.Source))
+
+	lineStart := strings.LastIndex(d.Source[:start], "\n") + 1
+	lineEnd := len(d.Source)
+	if i := strings.Index(d.Source[start:], "\n"); i >= 0 {
+		lineEnd = start + i
+	}
+
+	lineNumber := strings.Count(d.Source[:start], "\n") + 1
+	column := start - lineStart + 1
+
+	// The caret goes at least under one character, even at the end of the input.
+	width := clamp(end, start, lineEnd) - start
+	if width == 0 {
+		width = 1
+	}
+
+	gutter := strings.Repeat(" ", len(fmt.Sprint(lineNumber)))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%v--> line %v, column %v\n", gutter, lineNumber, column)
+	fmt.Fprintf(&sb, "%v |\n", gutter)
+	fmt.Fprintf(&sb, "%v | %v\n", lineNumber, d.Source[lineStart:lineEnd])
+	fmt.Fprintf(&sb, "%v | %v%v", gutter, strings.Repeat(" ", start-lineStart), strings.Repeat("^", width))
+
+	return sb.String()
+}
+
+func clamp(val, min, max int) int {
+	if val < min {
+		return min
+	}
+
+	if val > max {
+		return max
+	}
+
+	return val
+}
+
+// exprError is an error raised while type checking or evaluating a given node of the AST.
+// Its message is kept as is, so it can be located later on in the source the node was parsed from.
+type exprError struct {
+	expr    Expr
+	message string
+}
+
+func (e *exprError) Error() string {
+	return e.message
+}
+
+func exprErrorf(expr Expr, format string, a ...interface{}) error {
+	return &exprError{
+		expr:    expr,
+		message: fmt.Sprintf(format, a...),
+	}
+}
+
+// sourceMap keeps the source of a parsed expression along with the span of each node of its AST.
+type sourceMap struct {
+	source string
+	spans  map[Expr]Span
+}
+
+// locate turns an error raised on a node of the AST into a diagnostic pointing to the node in the source.
+// Any other error is returned unchanged.
+func (s *sourceMap) locate(err error) error {
+	var exprErr *exprError
+	if s == nil || !errors.As(err, &exprErr) {
+		return err
+	}
+
+	span, ok := s.spans[exprErr.expr]
+	if !ok {
+		return err
+	}
+
+	return &Diagnostic{
+		Message: err.Error(),
+		Source:  s.source,
+		Span:    span,
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnosticError(t *testing.T) {
+	diagnostic := &Diagnostic{
+		Message: "type inference failed: mismatch in arg types on startsWith",
+		Source:  `$size() < 10 && $startsWith($title(), 1)`,
+		Span:    Span{16, 41},
+	}
+
+	wantErr := `type inference failed: mismatch in arg types on startsWith
+ --> line 1, column 17
+  |
+1 | $size() < 10 && $startsWith($title(), 1)
+  |                 ^^^^^^^^^^^^^^^^^^^^^^^^`
+
+	assert.EqualError(t, diagnostic, wantErr)
+}
+
+func TestDiagnosticSnippet_WhenSourceHasManyLines(t *testing.T) {
+	diagnostic := &Diagnostic{
+		Message: "no type for built-in titel",
+		Source:  "$size() < 10\n&& $titel() == \"\"",
+		Span:    Span{16, 22},
+	}
+
+	wantSnippet := ` --> line 2, column 4
+  |
+2 | && $titel() == ""
+  |    ^^^^^^`
+
+	assert.Equal(t, wantSnippet, diagnostic.Snippet())
+}
+
+func TestDiagnosticSnippet_WhenSpanIsAtEndOfInput(t *testing.T) {
+	diagnostic := &Diagnostic{
+		Message: "parse error: unexpected end of input",
+		Source:  "$size() <",
+		Span:    Span{9, 9},
+	}
+
+	wantSnippet := ` --> line 1, column 10
+  |
+1 | $size() <
+  |          ^`
+
+	assert.Equal(t, wantSnippet, diagnostic.Snippet())
+}
+
+func TestLocate_WhenErrorIsNotOnExpr(t *testing.T) {
+	_, sourceMap, err := parse("1 == 1")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	wantErr := errors.New("some error")
+
+	assert.Equal(t, wantErr, sourceMap.locate(wantErr))
+}
+
+func TestLocate_WhenExprHasNoSpan(t *testing.T) {
+	_, sourceMap, err := parse("1 == 1")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	wantErr := exprErrorf(BuildIntConst(1), "type inference failed")
+
+	assert.Equal(t, wantErr, sourceMap.locate(wantErr))
+}
+
+func TestLocate_WhenTypeInferenceFails(t *testing.T) {
+	expr, sourceMap, err := parse(`$zeroConst() > 0 && $returnStr(1) == ""`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	_, err = expr.typeinfer(MockTypeEnv())
+
+	wantErr := `type inference failed: mismatch in arg types on returnStr
+ --> line 1, column 21
+  |
+1 | $zeroConst() > 0 && $returnStr(1) == ""
+  |                     ^^^^^^^^^^^^^`
+
+	assert.EqualError(t, sourceMap.locate(err), wantErr)
+}
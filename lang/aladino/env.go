@@ -32,6 +32,7 @@ type Env interface {
 	GetBuiltInsReportedMessages() map[Severity][]string
 	GetGithubClient() *gh.GithubClient
 	GetCollector() collector.Collector
+	GetMemo() *Memo
 	GetCtx() context.Context
 	GetDryRun() bool
 	GetEventPayload() interface{}
@@ -48,6 +49,7 @@ type BaseEnv struct {
 	Ctx                      context.Context
 	DryRun                   bool
 	EventPayload             interface{}
+	Memo                     *Memo
 	RegisterMap              RegisterMap
 	Report                   *Report
 	Target                   codehost.Target
@@ -69,6 +71,10 @@ func (e *BaseEnv) GetCollector() collector.Collector {
 	return e.Collector
 }
 
+func (e *BaseEnv) GetMemo() *Memo {
+	return e.Memo
+}
+
 func (e *BaseEnv) GetCtx() context.Context {
 	return e.Ctx
 }
@@ -94,12 +100,18 @@ func (e *BaseEnv) GetTarget() codehost.Target {
 }
 
 func NewTypeEnv(e Env) TypeEnv {
+	return BuildTypeEnv(e.GetBuiltIns())
+}
+
+// BuildTypeEnv builds the types of the built-ins without an evaluation environment,
+// e.g. to type check a reviewpad file without a target.
+func BuildTypeEnv(builtIns *BuiltIns) TypeEnv {
 	builtInsType := make(map[string]Type)
-	for builtInName, builtInFunction := range e.GetBuiltIns().Functions {
+	for builtInName, builtInFunction := range builtIns.Functions {
 		builtInsType[builtInName] = builtInFunction.Type
 	}
 
-	for builtInName, builtInAction := range e.GetBuiltIns().Actions {
+	for builtInName, builtInAction := range builtIns.Actions {
 		builtInsType[builtInName] = builtInAction.Type
 	}
 
@@ -126,6 +138,7 @@ func NewEvalEnv(
 		Ctx:                      ctx,
 		DryRun:                   dryRun,
 		EventPayload:             eventPayload,
+		Memo:                     NewMemo(),
 		RegisterMap:              registerMap,
 		Report:                   report,
 	}
@@ -5,7 +5,9 @@
 package aladino
 
 import (
-	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
 func (u *UnaryOp) Eval(e Env) (Value, error) {
@@ -25,13 +27,40 @@ func (b *BinaryOp) Eval(e Env) (Value, error) {
 		return nil, leftErr
 	}
 
+	// The right operand of && and || is only evaluated when the left operand does not decide the result.
+	switch b.op.getOperator() {
+	case AND_OP:
+		if leftBool, ok := leftValue.(*BoolValue); ok && !leftBool.Val {
+			return BuildFalseValue(), nil
+		}
+	case OR_OP:
+		if leftBool, ok := leftValue.(*BoolValue); ok && leftBool.Val {
+			return BuildTrueValue(), nil
+		}
+	}
+
 	rightValue, rightErr := b.rhs.Eval(e)
 	if rightErr != nil {
 		return nil, rightErr
 	}
 
-	if !leftValue.HasKindOf(rightValue.Kind()) {
-		return nil, fmt.Errorf("eval: left and right operand have different kinds")
+	// Only membership tests and the arithmetic of times and durations mix operands of different kinds,
+	// e.g. $author() in ["john"] or $createdAt() + 2 days.
+	isMembership := b.op.getOperator() == IN_OP || b.op.getOperator() == NOT_IN_OP
+	if !leftValue.HasKindOf(rightValue.Kind()) && !isMembership && !isTimeArithmetic(leftValue, rightValue) {
+		return nil, exprErrorf(b, "eval: left and right operand have different kinds")
+	}
+
+	switch b.op.getOperator() {
+	case DIV_OP, MOD_OP:
+		if rightValue.(*IntValue).Val == 0 {
+			return nil, exprErrorf(b.rhs, "eval: division by zero")
+		}
+	case MATCH_OP:
+		pattern := rightValue.(*StringValue).Val
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, exprErrorf(b.rhs, "eval: invalid regex %q: %v", pattern, err)
+		}
 	}
 
 	operator := b.op
@@ -48,18 +77,18 @@ func (v *Variable) Eval(e Env) (Value, error) {
 
 	fn, ok := e.GetBuiltIns().Functions[variableName]
 	if !ok {
-		return nil, fmt.Errorf("eval: failure on %v", variableName)
+		return nil, exprErrorf(v, "eval: failure on %v", variableName)
 	}
 
 	entityKind := e.GetTarget().GetTargetEntity().Kind
 
 	for _, supportedKind := range fn.SupportedKinds {
 		if entityKind == supportedKind {
-			return fn.Code(e, []Value{})
+			return e.GetMemo().call(variableName, fn, e, []Value{})
 		}
 	}
 
-	return nil, fmt.Errorf("eval: unsupported kind %v", entityKind)
+	return nil, exprErrorf(v, "eval: unsupported kind %v", entityKind)
 }
 
 func (b *BoolConst) Eval(e Env) (Value, error) {
@@ -74,6 +103,14 @@ func (i *IntConst) Eval(e Env) (Value, error) {
 	return BuildIntValue(i.value), nil
 }
 
+func (t *TimeConst) Eval(e Env) (Value, error) {
+	return BuildTimeValue(t.value), nil
+}
+
+func (d *DurationConst) Eval(e Env) (Value, error) {
+	return BuildDurationValue(d.value), nil
+}
+
 func (fc *FunctionCall) Eval(e Env) (Value, error) {
 	args := make([]Value, len(fc.arguments))
 	for i, elem := range fc.arguments {
@@ -88,40 +125,42 @@ func (fc *FunctionCall) Eval(e Env) (Value, error) {
 
 	fn, ok := e.GetBuiltIns().Functions[fc.name.ident]
 	if !ok {
-		return nil, fmt.Errorf("eval: failure on %v", fc.name.ident)
+		return nil, exprErrorf(fc, "eval: failure on %v", fc.name.ident)
 	}
 
 	entityKind := e.GetTarget().GetTargetEntity().Kind
 
 	for _, supportedKind := range fn.SupportedKinds {
 		if entityKind == supportedKind {
-			return fn.Code(e, args)
+			return e.GetMemo().call(fc.name.ident, fn, e, withDefaultArgs(fn.Type, args))
 		}
 	}
 
-	return nil, fmt.Errorf("eval: unsupported kind %v", entityKind)
+	return nil, exprErrorf(fc, "eval: unsupported kind %v", entityKind)
 }
 
 func (lambda *Lambda) Eval(e Env) (Value, error) {
-	fn := func(args []Value) Value {
-		// TODO: We need to deep copy the register map and use in the eval at L79
+	fn := func(args []Value) (Value, error) {
+		bindings := make(map[string]Value)
 		for i, elem := range lambda.parameters {
-			paramIdent := elem.(*TypedExpr).expr.(*Variable).ident
-
-			e.GetRegisterMap()[paramIdent] = args[i]
+			bindings[lambdaParameterIdent(elem)] = args[i]
 		}
 
-		fnVal, err := lambda.body.Eval(e)
-		if err != nil {
-			return nil
-		}
-
-		return fnVal
+		return evalWithBindings(e, bindings, lambda.body)
 	}
 
 	return BuildFunctionValue(fn), nil
 }
 
+// lambdaParameterIdent is the name of a lambda parameter, which may have a type annotation, e.g. $x or $x: Int.
+func lambdaParameterIdent(param Expr) string {
+	if typedParam, ok := param.(*TypedExpr); ok {
+		return typedParam.expr.(*Variable).ident
+	}
+
+	return param.(*Variable).ident
+}
+
 func (te *TypedExpr) Eval(e Env) (Value, error) {
 	return te.expr.Eval(e)
 }
@@ -141,6 +180,143 @@ func (a *Array) Eval(e Env) (Value, error) {
 	return BuildArrayValue(values), nil
 }
 
+func (l *Let) Eval(e Env) (Value, error) {
+	value, err := l.value.Eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return evalWithBindings(e, map[string]Value{l.variable.ident: value}, l.body)
+}
+
+func (c *Conditional) Eval(e Env) (Value, error) {
+	conditionValue, err := c.condition.Eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	condition, ok := conditionValue.(*BoolValue)
+	if !ok {
+		return nil, exprErrorf(c, "eval: condition is not a boolean")
+	}
+
+	// Only the chosen branch is evaluated.
+	if condition.Val {
+		return c.thenBranch.Eval(e)
+	}
+
+	return c.elseBranch.Eval(e)
+}
+
+func (fa *FieldAccess) Eval(e Env) (Value, error) {
+	value, err := fa.expr.Eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	record, ok := value.(*RecordValue)
+	if !ok {
+		return nil, exprErrorf(fa, "eval: field access on non-record value")
+	}
+
+	fieldValue, ok := record.Vals[fa.field]
+	if !ok {
+		return nil, exprErrorf(fa, "eval: no field %v in record", fa.field)
+	}
+
+	return fieldValue, nil
+}
+
+func (i *Interpolation) Eval(e Env) (Value, error) {
+	var sb strings.Builder
+	for _, part := range i.parts {
+		value, err := part.Eval(e)
+		if err != nil {
+			return nil, err
+		}
+
+		sb.WriteString(interpolatedString(value))
+	}
+
+	return BuildStringValue(sb.String()), nil
+}
+
+// interpolatedString converts a value into the text that replaces it in an interpolation.
+// Arrays are converted into the comma separated list of their elements.
+// Pre-condition: the value has an interpolable type
+func interpolatedString(value Value) string {
+	switch val := value.(type) {
+	case *StringValue:
+		return val.Val
+	case *IntValue:
+		return strconv.Itoa(val.Val)
+	case *BoolValue:
+		return strconv.FormatBool(val.Val)
+	case *TimeValue:
+		return val.String()
+	case *DurationValue:
+		return val.String()
+	case *ArrayValue:
+		elems := make([]string, len(val.Vals))
+		for i, elem := range val.Vals {
+			elems[i] = interpolatedString(elem)
+		}
+		return strings.Join(elems, ", ")
+	}
+
+	return ""
+}
+
+// isTimeArithmetic checks if any operand is a time or a duration.
+func isTimeArithmetic(lhs, rhs Value) bool {
+	for _, value := range []Value{lhs, rhs} {
+		if value.HasKindOf(TIME_VALUE) || value.HasKindOf(DURATION_VALUE) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// orderedValue is the number used to compare an integer, time or duration value.
+func orderedValue(value Value) int {
+	switch val := value.(type) {
+	case *TimeValue:
+		return val.Val
+	case *DurationValue:
+		return val.Val
+	}
+
+	return value.(*IntValue).Val
+}
+
+// evalWithBindings evaluates expr with the bindings added to the register map.
+// Any register shadowed by a binding is restored once the evaluation is done.
+func evalWithBindings(e Env, bindings map[string]Value, expr Expr) (Value, error) {
+	registerMap := e.GetRegisterMap()
+	shadowedValues := make(map[string]Value)
+
+	for ident, value := range bindings {
+		if shadowedValue, ok := registerMap[ident]; ok {
+			shadowedValues[ident] = shadowedValue
+		}
+
+		registerMap[ident] = value
+	}
+
+	defer func() {
+		for ident := range bindings {
+			if shadowedValue, ok := shadowedValues[ident]; ok {
+				registerMap[ident] = shadowedValue
+			} else {
+				delete(registerMap, ident)
+			}
+		}
+	}()
+
+	return expr.Eval(e)
+}
+
 func Eval(env Env, expr Expr) (Value, error) {
 	val, err := expr.Eval(env)
 
@@ -190,29 +366,124 @@ func (op *OrOp) Eval(lhs, rhs Value) Value {
 }
 
 func (op *LessThanOp) Eval(lhs, rhs Value) Value {
-	leftValue := lhs.(*IntValue).Val
-	rightValue := rhs.(*IntValue).Val
+	leftValue := orderedValue(lhs)
+	rightValue := orderedValue(rhs)
 
 	return BuildBoolValue(leftValue < rightValue)
 }
 
 func (op *LessEqThanOp) Eval(lhs, rhs Value) Value {
-	leftValue := lhs.(*IntValue).Val
-	rightValue := rhs.(*IntValue).Val
+	leftValue := orderedValue(lhs)
+	rightValue := orderedValue(rhs)
 
 	return BuildBoolValue(leftValue <= rightValue)
 }
 
 func (op *GreaterThanOp) Eval(lhs, rhs Value) Value {
-	leftValue := lhs.(*IntValue).Val
-	rightValue := rhs.(*IntValue).Val
+	leftValue := orderedValue(lhs)
+	rightValue := orderedValue(rhs)
 
 	return BuildBoolValue(leftValue > rightValue)
 }
 
 func (op *GreaterEqThanOp) Eval(lhs, rhs Value) Value {
+	leftValue := orderedValue(lhs)
+	rightValue := orderedValue(rhs)
+
+	return BuildBoolValue(leftValue >= rightValue)
+}
+
+func (op *AddOp) Eval(lhs, rhs Value) Value {
+	if lhs.HasKindOf(STRING_VALUE) {
+		return BuildStringValue(lhs.(*StringValue).Val + rhs.(*StringValue).Val)
+	}
+
+	switch {
+	case lhs.HasKindOf(TIME_VALUE):
+		return BuildTimeValue(lhs.(*TimeValue).Val + rhs.(*DurationValue).Val)
+	case rhs.HasKindOf(TIME_VALUE):
+		return BuildTimeValue(lhs.(*DurationValue).Val + rhs.(*TimeValue).Val)
+	case lhs.HasKindOf(DURATION_VALUE):
+		return BuildDurationValue(lhs.(*DurationValue).Val + rhs.(*DurationValue).Val)
+	}
+
 	leftValue := lhs.(*IntValue).Val
 	rightValue := rhs.(*IntValue).Val
 
-	return BuildBoolValue(leftValue >= rightValue)
+	return BuildIntValue(leftValue + rightValue)
+}
+
+func (op *SubOp) Eval(lhs, rhs Value) Value {
+	switch {
+	case lhs.HasKindOf(TIME_VALUE) && rhs.HasKindOf(TIME_VALUE):
+		return BuildDurationValue(lhs.(*TimeValue).Val - rhs.(*TimeValue).Val)
+	case lhs.HasKindOf(TIME_VALUE):
+		return BuildTimeValue(lhs.(*TimeValue).Val - rhs.(*DurationValue).Val)
+	case lhs.HasKindOf(DURATION_VALUE):
+		return BuildDurationValue(lhs.(*DurationValue).Val - rhs.(*DurationValue).Val)
+	}
+
+	leftValue := lhs.(*IntValue).Val
+	rightValue := rhs.(*IntValue).Val
+
+	return BuildIntValue(leftValue - rightValue)
+}
+
+func (op *MulOp) Eval(lhs, rhs Value) Value {
+	switch {
+	case lhs.HasKindOf(DURATION_VALUE):
+		return BuildDurationValue(lhs.(*DurationValue).Val * rhs.(*IntValue).Val)
+	case rhs.HasKindOf(DURATION_VALUE):
+		return BuildDurationValue(lhs.(*IntValue).Val * rhs.(*DurationValue).Val)
+	}
+
+	leftValue := lhs.(*IntValue).Val
+	rightValue := rhs.(*IntValue).Val
+
+	return BuildIntValue(leftValue * rightValue)
+}
+
+func (op *DivOp) Eval(lhs, rhs Value) Value {
+	if lhs.HasKindOf(DURATION_VALUE) {
+		return BuildDurationValue(lhs.(*DurationValue).Val / rhs.(*IntValue).Val)
+	}
+
+	leftValue := lhs.(*IntValue).Val
+	rightValue := rhs.(*IntValue).Val
+
+	return BuildIntValue(leftValue / rightValue)
+}
+
+func (op *ModOp) Eval(lhs, rhs Value) Value {
+	leftValue := lhs.(*IntValue).Val
+	rightValue := rhs.(*IntValue).Val
+
+	return BuildIntValue(leftValue % rightValue)
+}
+
+func (op *MatchOp) Eval(lhs, rhs Value) Value {
+	leftValue := lhs.(*StringValue).Val
+	rightValue := rhs.(*StringValue).Val
+
+	isMatch, _ := regexp.MatchString(rightValue, leftValue)
+
+	return BuildBoolValue(isMatch)
+}
+
+func (op *InOp) Eval(lhs, rhs Value) Value {
+	return BuildBoolValue(isElement(lhs, rhs.(*ArrayValue).Vals))
+}
+
+func (op *NotInOp) Eval(lhs, rhs Value) Value {
+	return BuildBoolValue(!isElement(lhs, rhs.(*ArrayValue).Vals))
+}
+
+func isElement(value Value, values []Value) bool {
+	for _, elem := range values {
+		if value.Equals(elem) {
+			return true
+		}
+	}
+
+	return false
 }
@@ -6,7 +6,9 @@ package aladino_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/reviewpad/reviewpad/v3/handler"
 	"github.com/reviewpad/reviewpad/v3/lang/aladino"
 	"github.com/stretchr/testify/assert"
 )
@@ -115,6 +117,115 @@ func TestEval_OnBinaryOp_WhenFalse(t *testing.T) {
 	assert.Equal(t, wantVal, gotVal)
 }
 
+func TestEval_OnBinaryOp_WithArithmeticPrecedence(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	binaryOp, err := aladino.Parse("1 + 2 * 3 - 8 / 4 % 3 == 5")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := binaryOp.Eval(mockedEnv)
+
+	wantVal := aladino.BuildTrueValue()
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnBinaryOp_WithShortCircuit(t *testing.T) {
+	tests := map[string]struct {
+		input          string
+		wantVal        aladino.Value
+		wantTotalCalls int
+	}{
+		"and when left is false": {
+			input:          "1 > 2 && $counter()",
+			wantVal:        aladino.BuildFalseValue(),
+			wantTotalCalls: 0,
+		},
+		"and when left is true": {
+			input:          "1 < 2 && $counter()",
+			wantVal:        aladino.BuildTrueValue(),
+			wantTotalCalls: 1,
+		},
+		"or when left is true": {
+			input:          "1 < 2 || $counter()",
+			wantVal:        aladino.BuildTrueValue(),
+			wantTotalCalls: 0,
+		},
+		"or when left is false": {
+			input:          "1 > 2 || $counter()",
+			wantVal:        aladino.BuildTrueValue(),
+			wantTotalCalls: 1,
+		},
+		"guarded right operand": {
+			input:          "$zeroConst() > 0 && 1 / $zeroConst() > 0",
+			wantVal:        aladino.BuildFalseValue(),
+			wantTotalCalls: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			totalCalls := 0
+			builtIns := aladino.MockBuiltIns()
+			builtIns.Functions["counter"] = &aladino.BuiltInFunction{
+				Type: aladino.BuildFunctionType([]aladino.Type{}, aladino.BuildBoolType()),
+				Code: func(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+					totalCalls++
+					return aladino.BuildTrueValue(), nil
+				},
+				SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+			}
+			mockedEnv := aladino.MockDefaultEnv(t, nil, nil, builtIns, nil)
+
+			binaryOp, err := aladino.Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotVal, err := binaryOp.Eval(mockedEnv)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantVal, gotVal)
+			assert.Equal(t, test.wantTotalCalls, totalCalls)
+		})
+	}
+}
+
+func TestEval_OnBinaryOp_WhenDivisionByZero(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	binaryOp, err := aladino.Parse("1 / $zeroConst()")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := binaryOp.Eval(mockedEnv)
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "eval: division by zero")
+}
+
+func TestEval_OnBinaryOp_WhenInvalidRegex(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+	mockedEnv.GetRegisterMap()["pattern"] = aladino.BuildStringValue("^(feat")
+
+	binaryOp, err := aladino.Parse(`"feat: add" =~ $pattern`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := binaryOp.Eval(mockedEnv)
+
+	// clean up
+	delete(mockedEnv.GetRegisterMap(), "pattern")
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "eval: invalid regex \"^(feat\": error parsing regexp: missing closing ): `^(feat`")
+}
+
 func TestEval_OnVariable_WhenVariableIsRegistered(t *testing.T) {
 	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
 
@@ -260,6 +371,49 @@ func TestEval_OnFunctionCall_WhenFunctionIsABuiltIn(t *testing.T) {
 	assert.Equal(t, wantVal, gotVal)
 }
 
+func TestEval_OnFunctionCall_WhenOptionalArgsAreOmitted(t *testing.T) {
+	builtIns := aladino.MockBuiltIns()
+	builtIns.Functions["join"] = &aladino.BuiltInFunction{
+		Type: aladino.BuildFunctionTypeWithDefaults(
+			[]aladino.Type{aladino.BuildStringType(), aladino.BuildStringType(), aladino.BuildStringType()},
+			aladino.BuildStringType(),
+			[]aladino.Value{aladino.BuildStringValue("world"), aladino.BuildStringValue("!")},
+		),
+		Code: func(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+			joined := ""
+			for _, arg := range args {
+				joined += arg.(*aladino.StringValue).Val
+			}
+			return aladino.BuildStringValue(joined), nil
+		},
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+	}
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, builtIns, nil)
+
+	tests := map[string]struct {
+		input   string
+		wantVal aladino.Value
+	}{
+		"all args":              {input: `$join("hello ", "john", "?")`, wantVal: aladino.BuildStringValue("hello john?")},
+		"last arg omitted":      {input: `$join("hello ", "john")`, wantVal: aladino.BuildStringValue("hello john!")},
+		"optional args omitted": {input: `$join("hello ")`, wantVal: aladino.BuildStringValue("hello world!")},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fc, err := aladino.Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotVal, err := fc.Eval(mockedEnv)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantVal, gotVal)
+		})
+	}
+}
+
 func TestEval_OnLambda_WhenLambdaBodyEvalFails(t *testing.T) {
 	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
 
@@ -272,9 +426,11 @@ func TestEval_OnLambda_WhenLambdaBodyEvalFails(t *testing.T) {
 
 	gotFn, err := lambda.Eval(mockedEnv)
 
-	gotVal := gotFn.(*aladino.FunctionValue).Fn([]aladino.Value{})
-
 	assert.Nil(t, err)
+
+	gotVal, gotErr := gotFn.(*aladino.FunctionValue).Fn([]aladino.Value{})
+
+	assert.EqualError(t, gotErr, "eval: failure on nonBuiltIn")
 	assert.Nil(t, gotVal)
 }
 
@@ -294,14 +450,201 @@ func TestEval_OnLambda(t *testing.T) {
 
 	gotFn, err := lambda.Eval(mockedEnv)
 
-	gotVal := gotFn.(*aladino.FunctionValue).Fn([]aladino.Value{aladino.BuildIntValue(0)})
+	assert.Nil(t, err)
+
+	gotVal, gotErr := gotFn.(*aladino.FunctionValue).Fn([]aladino.Value{aladino.BuildIntValue(0)})
 
 	wantVal := aladino.BuildTrueValue()
 
+	assert.Nil(t, gotErr)
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnLet_WhenValueEvalFails(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	let, err := aladino.Parse("let $x = $nonBuiltIn(); $x")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := let.Eval(mockedEnv)
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "eval: failure on nonBuiltIn")
+}
+
+func TestEval_OnLet(t *testing.T) {
+	totalCalls := 0
+	builtIns := aladino.MockBuiltIns()
+	builtIns.Functions["counter"] = &aladino.BuiltInFunction{
+		Type: aladino.BuildFunctionType([]aladino.Type{}, aladino.BuildIntType()),
+		Code: func(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+			totalCalls++
+			return aladino.BuildIntValue(totalCalls), nil
+		},
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+	}
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, builtIns, nil)
+
+	let, err := aladino.Parse("let $x = $counter(); $x + $x == 2")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := let.Eval(mockedEnv)
+
+	_, isBound := mockedEnv.GetRegisterMap()["x"]
+
+	wantVal := aladino.BuildTrueValue()
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantVal, gotVal)
+	assert.Equal(t, 1, totalCalls)
+	assert.False(t, isBound)
+}
+
+func TestEval_OnLet_WhenShadowingRegister(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+	mockedEnv.GetRegisterMap()["x"] = aladino.BuildStringValue("outer")
+
+	let, err := aladino.Parse("let $x = \"inner\"; $x")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := let.Eval(mockedEnv)
+
+	wantVal := aladino.BuildStringValue("inner")
+	wantRegister := aladino.BuildStringValue("outer")
+	gotRegister := mockedEnv.GetRegisterMap()["x"]
+
+	// clean up
+	delete(mockedEnv.GetRegisterMap(), "x")
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantVal, gotVal)
+	assert.Equal(t, wantRegister, gotRegister)
+}
+
+func TestEval_OnConditional(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		wantVal aladino.Value
+	}{
+		"then branch": {
+			input:   `if 1 < 2 then "then" else "else"`,
+			wantVal: aladino.BuildStringValue("then"),
+		},
+		"else branch": {
+			input:   `if 1 > 2 then "then" else "else"`,
+			wantVal: aladino.BuildStringValue("else"),
+		},
+	}
+
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			conditional, err := aladino.Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotVal, err := conditional.Eval(mockedEnv)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantVal, gotVal)
+		})
+	}
+}
+
+func TestEval_OnConditional_OnlyEvaluatesChosenBranch(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	// the else branch would fail with a division by zero if evaluated
+	conditional, err := aladino.Parse("if true then 1 else 1 / 0")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := conditional.Eval(mockedEnv)
+
+	wantVal := aladino.BuildIntValue(1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnConditional_WhenConditionEvalFails(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	conditional, err := aladino.Parse("if $nonBuiltIn() then 1 else 2")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := conditional.Eval(mockedEnv)
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "eval: failure on nonBuiltIn")
+}
+
+func TestEval_OnFieldAccess(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+	mockedEnv.GetRegisterMap()["review"] = aladino.BuildRecordValue(map[string]aladino.Value{
+		"user":  aladino.BuildStringValue("john"),
+		"state": aladino.BuildStringValue("APPROVED"),
+	})
+
+	fieldAccess, err := aladino.Parse(`$review.state`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := fieldAccess.Eval(mockedEnv)
+
+	// clean up
+	delete(mockedEnv.GetRegisterMap(), "review")
+
+	wantVal := aladino.BuildStringValue("APPROVED")
+
 	assert.Nil(t, err)
 	assert.Equal(t, wantVal, gotVal)
 }
 
+func TestEval_OnFieldAccess_WhenFieldIsMissing(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+	mockedEnv.GetRegisterMap()["review"] = aladino.BuildRecordValue(map[string]aladino.Value{})
+
+	fieldAccess, err := aladino.Parse(`$review.state`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := fieldAccess.Eval(mockedEnv)
+
+	// clean up
+	delete(mockedEnv.GetRegisterMap(), "review")
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "eval: no field state in record")
+}
+
+func TestEval_OnFieldAccess_WhenNotARecord(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	fieldAccess, err := aladino.Parse(`"john".user`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := fieldAccess.Eval(mockedEnv)
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "eval: field access on non-record value")
+}
+
 func TestEval_OnTypedExpr(t *testing.T) {
 	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
 
@@ -502,3 +845,177 @@ func TestEval_OnGreaterEqThanOp(t *testing.T) {
 
 	assert.Equal(t, wantVal, gotVal)
 }
+
+func TestEval_OnAddOp_WhenInts(t *testing.T) {
+	addOp := &aladino.AddOp{}
+	gotVal := addOp.Eval(aladino.BuildIntValue(3), aladino.BuildIntValue(2))
+
+	wantVal := aladino.BuildIntValue(5)
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnAddOp_WhenStrings(t *testing.T) {
+	addOp := &aladino.AddOp{}
+	gotVal := addOp.Eval(aladino.BuildStringValue("foo"), aladino.BuildStringValue("bar"))
+
+	wantVal := aladino.BuildStringValue("foobar")
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnSubOp(t *testing.T) {
+	subOp := &aladino.SubOp{}
+	gotVal := subOp.Eval(aladino.BuildIntValue(3), aladino.BuildIntValue(2))
+
+	wantVal := aladino.BuildIntValue(1)
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnMulOp(t *testing.T) {
+	mulOp := &aladino.MulOp{}
+	gotVal := mulOp.Eval(aladino.BuildIntValue(3), aladino.BuildIntValue(2))
+
+	wantVal := aladino.BuildIntValue(6)
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnDivOp(t *testing.T) {
+	divOp := &aladino.DivOp{}
+	gotVal := divOp.Eval(aladino.BuildIntValue(7), aladino.BuildIntValue(2))
+
+	wantVal := aladino.BuildIntValue(3)
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnModOp(t *testing.T) {
+	modOp := &aladino.ModOp{}
+	gotVal := modOp.Eval(aladino.BuildIntValue(7), aladino.BuildIntValue(2))
+
+	wantVal := aladino.BuildIntValue(1)
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnMatchOp_WhenTrue(t *testing.T) {
+	matchOp := &aladino.MatchOp{}
+	gotVal := matchOp.Eval(aladino.BuildStringValue("feat: add regex"), aladino.BuildStringValue("^(feat|fix):"))
+
+	wantVal := aladino.BuildTrueValue()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnMatchOp_WhenFalse(t *testing.T) {
+	matchOp := &aladino.MatchOp{}
+	gotVal := matchOp.Eval(aladino.BuildStringValue("docs: add regex"), aladino.BuildStringValue("^(feat|fix):"))
+
+	wantVal := aladino.BuildFalseValue()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnInOp_WhenTrue(t *testing.T) {
+	inOp := &aladino.InOp{}
+	gotVal := inOp.Eval(aladino.BuildStringValue("john"), aladino.BuildArrayValue([]aladino.Value{aladino.BuildStringValue("jane"), aladino.BuildStringValue("john")}))
+
+	wantVal := aladino.BuildTrueValue()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnNotInOp_WhenFalse(t *testing.T) {
+	notInOp := &aladino.NotInOp{}
+	gotVal := notInOp.Eval(aladino.BuildStringValue("john"), aladino.BuildArrayValue([]aladino.Value{aladino.BuildStringValue("john")}))
+
+	wantVal := aladino.BuildFalseValue()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnBinaryOp_WithMembership(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	tests := map[string]struct {
+		input   string
+		wantVal aladino.Value
+	}{
+		"element in array":       {input: `$returnStr("john") in ["jane", "john"]`, wantVal: aladino.BuildTrueValue()},
+		"element not in array":   {input: `$zeroConst() not in [1, 2]`, wantVal: aladino.BuildTrueValue()},
+		"element in empty array": {input: `"john" in []`, wantVal: aladino.BuildFalseValue()},
+		"array in array":         {input: `[1, 2] in [[1], [1, 2]]`, wantVal: aladino.BuildTrueValue()},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := aladino.Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotVal, err := expr.Eval(mockedEnv)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantVal, gotVal)
+		})
+	}
+}
+
+func TestEval_OnInterpolation(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	interpolation, err := aladino.Parse(`"Hi @${returnStr("john")}, zero is ${zeroConst()} ${[1, 2] == [1, 2]} in ${["a", "b"]} \${id}"`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := interpolation.Eval(mockedEnv)
+
+	wantVal := aladino.BuildStringValue("Hi @john, zero is 0 true in a, b ${id}")
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEval_OnTimeArithmetic(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+	day := 24 * 60 * 60
+	april5 := int(time.Date(2022, 4, 5, 0, 0, 0, 0, time.UTC).Unix())
+
+	tests := map[string]struct {
+		input   string
+		wantVal aladino.Value
+	}{
+		"time minus time":         {input: `2022-04-07 - 2022-04-05`, wantVal: aladino.BuildDurationValue(2 * day)},
+		"time plus duration":      {input: `2022-04-05 + 1 day`, wantVal: aladino.BuildTimeValue(april5 + day)},
+		"duration plus time":      {input: `1 day + 2022-04-05`, wantVal: aladino.BuildTimeValue(april5 + day)},
+		"time minus duration":     {input: `2022-04-05 - 1 hour`, wantVal: aladino.BuildTimeValue(april5 - 60*60)},
+		"duration minus duration": {input: `1 day - 1 hour`, wantVal: aladino.BuildDurationValue(23 * 60 * 60)},
+		"duration times int":      {input: `2 * 1 day * 3`, wantVal: aladino.BuildDurationValue(6 * day)},
+		"duration divided by int": {input: `1 day / 4`, wantVal: aladino.BuildDurationValue(6 * 60 * 60)},
+		"comparison of times":     {input: `2022-04-05 < 2022-04-06`, wantVal: aladino.BuildTrueValue()},
+		"comparison of durations": {input: `2022-04-07 - 2022-04-05 >= 3 days`, wantVal: aladino.BuildFalseValue()},
+		"equality of durations":   {input: `2 days == 48 hours`, wantVal: aladino.BuildTrueValue()},
+		"interpolation": {
+			input:   `"opened on ${2022-04-05} for ${2022-04-07 - 2022-04-05 + 90 minutes}"`,
+			wantVal: aladino.BuildStringValue("opened on 2022-04-05T00:00:00Z for 2 days 1 hour 30 minutes"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := aladino.Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotVal, err := aladino.Eval(mockedEnv, expr)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantVal, gotVal)
+		})
+	}
+}
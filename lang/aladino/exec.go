@@ -4,10 +4,6 @@
 
 package aladino
 
-import (
-	"fmt"
-)
-
 type ExecExpr interface {
 	exec(env Env) error
 }
@@ -23,7 +19,7 @@ func TypeCheckExec(env Env, expr Expr) (ExecExpr, error) {
 		return expr.(*FunctionCall), nil
 	}
 
-	return nil, fmt.Errorf("typecheckexec: %v", expr.Kind())
+	return nil, exprErrorf(expr, "typecheckexec: %v", expr.Kind())
 }
 
 func (fc *FunctionCall) exec(env Env) error {
@@ -40,7 +36,7 @@ func (fc *FunctionCall) exec(env Env) error {
 
 	action, ok := env.GetBuiltIns().Actions[fc.name.ident]
 	if !ok {
-		return fmt.Errorf("exec: %v not found. are you sure this is a built-in function?", fc.name.ident)
+		return exprErrorf(fc, "exec: %v not found. are you sure this is a built-in function?", fc.name.ident)
 	}
 
 	if action.Disabled {
@@ -58,9 +54,9 @@ func (fc *FunctionCall) exec(env Env) error {
 
 	for _, supportedKind := range action.SupportedKinds {
 		if entityKind == supportedKind {
-			return action.Code(env, args)
+			return action.Code(env, withDefaultArgs(action.Type, args))
 		}
 	}
 
-	return fmt.Errorf("eval: unsupported kind %v", entityKind)
+	return exprErrorf(fc, "eval: unsupported kind %v", entityKind)
 }
@@ -117,6 +117,38 @@ func TestExec_WhenActionIsEnabled(t *testing.T) {
 	assert.True(t, isBuiltInCalled)
 }
 
+func TestExec_WhenOptionalArgIsOmitted(t *testing.T) {
+	builtInName := "emptyAction"
+
+	var gotArgs []Value
+
+	builtIns := &BuiltIns{
+		Actions: map[string]*BuiltInAction{
+			builtInName: {
+				Type: BuildFunctionTypeWithDefaults([]Type{BuildStringType(), BuildIntType()}, nil, []Value{BuildIntValue(99)}),
+				Code: func(e Env, args []Value) error {
+					gotArgs = args
+					return nil
+				},
+				SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+			},
+		},
+	}
+	mockedEnv := MockDefaultEnv(t, nil, nil, builtIns, nil)
+
+	fc := &FunctionCall{
+		name:      BuildVariable(builtInName),
+		arguments: []Expr{BuildStringConst("john")},
+	}
+
+	err := fc.exec(mockedEnv)
+
+	wantArgs := []Value{BuildStringValue("john"), BuildIntValue(99)}
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantArgs, gotArgs)
+}
+
 func TestExec_WhenActionIsDisabled(t *testing.T) {
 	builtInName := "emptyAction"
 
@@ -9,6 +9,7 @@ import (
 	"log"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/reviewpad/reviewpad/v3/utils/report"
@@ -19,6 +20,7 @@ type Expr interface {
 	typeinfer(env TypeEnv) (Type, error)
 	Eval(Env) (Value, error)
 	equals(Expr) bool
+	print() string
 }
 
 const (
@@ -26,6 +28,7 @@ const (
 	INT_CONST           string = "IntConst"
 	STRING_CONST        string = "StringConst"
 	TIME_CONST          string = "TimeConst"
+	DURATION_CONST      string = "DurationConst"
 	VARIABLE_CONST      string = "Variable"
 	UNARY_OP_CONST      string = "UnaryOp"
 	BINARY_OP_CONST     string = "BinaryOp"
@@ -33,6 +36,10 @@ const (
 	LAMBDA_CONST        string = "Lambda"
 	TYPED_EXPR          string = "TypedExpr"
 	ARRAY_CONST         string = "Array"
+	LET_CONST           string = "Let"
+	CONDITIONAL_CONST   string = "Conditional"
+	FIELD_ACCESS_CONST  string = "FieldAccess"
+	INTERPOLATION_CONST string = "Interpolation"
 	NOT_OP              string = "!"
 	EQ_OP               string = "=="
 	NEQ_OP              string = "!="
@@ -42,6 +49,14 @@ const (
 	LESS_EQ_THAN_OP     string = "<="
 	GREATER_THAN_OP     string = ">"
 	GREATER_EQ_THAN_OP  string = ">="
+	ADD_OP              string = "+"
+	SUB_OP              string = "-"
+	MUL_OP              string = "*"
+	DIV_OP              string = "/"
+	MOD_OP              string = "%"
+	MATCH_OP            string = "=~"
+	IN_OP               string = "in"
+	NOT_IN_OP           string = "not in"
 )
 
 type UnaryOperator interface {
@@ -68,6 +83,14 @@ type LessThanOp struct{}
 type LessEqThanOp struct{}
 type GreaterThanOp struct{}
 type GreaterEqThanOp struct{}
+type AddOp struct{}
+type SubOp struct{}
+type MulOp struct{}
+type DivOp struct{}
+type ModOp struct{}
+type MatchOp struct{}
+type InOp struct{}
+type NotInOp struct{}
 
 func eqOperator() *EqOp                       { return &EqOp{} }
 func neqOperator() *NeqOp                     { return &NeqOp{} }
@@ -77,6 +100,14 @@ func lessThanOperator() *LessThanOp           { return &LessThanOp{} }
 func lessEqThanOperator() *LessEqThanOp       { return &LessEqThanOp{} }
 func greaterThanOperator() *GreaterThanOp     { return &GreaterThanOp{} }
 func greaterEqThanOperator() *GreaterEqThanOp { return &GreaterEqThanOp{} }
+func addOperator() *AddOp                     { return &AddOp{} }
+func subOperator() *SubOp                     { return &SubOp{} }
+func mulOperator() *MulOp                     { return &MulOp{} }
+func divOperator() *DivOp                     { return &DivOp{} }
+func modOperator() *ModOp                     { return &ModOp{} }
+func matchOperator() *MatchOp                 { return &MatchOp{} }
+func inOperator() *InOp                       { return &InOp{} }
+func notInOperator() *NotInOp                 { return &NotInOp{} }
 
 func (op *EqOp) getOperator() string            { return EQ_OP }
 func (op *NeqOp) getOperator() string           { return NEQ_OP }
@@ -86,6 +117,14 @@ func (op *LessThanOp) getOperator() string      { return LESS_THAN_OP }
 func (op *LessEqThanOp) getOperator() string    { return LESS_EQ_THAN_OP }
 func (op *GreaterThanOp) getOperator() string   { return GREATER_THAN_OP }
 func (op *GreaterEqThanOp) getOperator() string { return GREATER_EQ_THAN_OP }
+func (op *AddOp) getOperator() string           { return ADD_OP }
+func (op *SubOp) getOperator() string           { return SUB_OP }
+func (op *MulOp) getOperator() string           { return MUL_OP }
+func (op *DivOp) getOperator() string           { return DIV_OP }
+func (op *ModOp) getOperator() string           { return MOD_OP }
+func (op *MatchOp) getOperator() string         { return MATCH_OP }
+func (op *InOp) getOperator() string            { return IN_OP }
+func (op *NotInOp) getOperator() string         { return NOT_IN_OP }
 
 type BoolConst struct {
 	value bool
@@ -147,8 +186,28 @@ func (thisInt *IntConst) equals(other Expr) bool {
 	return thisInt.value == other.(*IntConst).value
 }
 
-func BuildRelativeTimeConst(val string) *IntConst {
+// TimeConst is an instant in time as the number of seconds since the Unix epoch
+type TimeConst struct {
+	value int
+	// relative is the source of relative times, e.g. 3 days ago, which is kept to print them back.
+	relative string
+}
+
+func (t *TimeConst) Kind() string {
+	return TIME_CONST
+}
+
+func (thisTime *TimeConst) equals(other Expr) bool {
+	if thisTime.Kind() != other.Kind() {
+		return false
+	}
+
+	return thisTime.value == other.(*TimeConst).value
+}
+
+func BuildRelativeTimeConst(val string) *TimeConst {
 	now := time.Now()
+	relative := strings.Join(strings.Fields(val), " ")
 
 	timeUnitRegex := regexp.MustCompile(`year|month|week|day|hour|minute`)
 	timeUnit := timeUnitRegex.FindString(val)
@@ -161,39 +220,43 @@ func BuildRelativeTimeConst(val string) *IntConst {
 
 	switch timeUnit {
 	case "year":
-		var a = now.AddDate(-timeValue, 0, 0)
-		a.UnixMilli()
-		return &IntConst{
-			value: int(now.AddDate(-timeValue, 0, 0).Unix()),
+		return &TimeConst{
+			value:    int(now.AddDate(-timeValue, 0, 0).Unix()),
+			relative: relative,
 		}
 	case "month":
-		return &IntConst{
-			value: int(now.AddDate(0, -timeValue, 0).Unix()),
+		return &TimeConst{
+			value:    int(now.AddDate(0, -timeValue, 0).Unix()),
+			relative: relative,
 		}
 	case "day":
-		return &IntConst{
-			value: int(now.AddDate(0, 0, -timeValue).Unix()),
+		return &TimeConst{
+			value:    int(now.AddDate(0, 0, -timeValue).Unix()),
+			relative: relative,
 		}
 	case "week":
 		week := time.Hour * 24 * 7
-		return &IntConst{
-			value: int(now.Add(-week * time.Duration(timeValue)).Unix()),
+		return &TimeConst{
+			value:    int(now.Add(-week * time.Duration(timeValue)).Unix()),
+			relative: relative,
 		}
 	case "hour":
-		return &IntConst{
-			value: int(now.Add(-time.Hour * time.Duration(timeValue)).Unix()),
+		return &TimeConst{
+			value:    int(now.Add(-time.Hour * time.Duration(timeValue)).Unix()),
+			relative: relative,
 		}
 	case "minute":
-		return &IntConst{
-			value: int(now.Add(-time.Minute * time.Duration(timeValue)).Unix()),
+		return &TimeConst{
+			value:    int(now.Add(-time.Minute * time.Duration(timeValue)).Unix()),
+			relative: relative,
 		}
 	}
 
 	log.Fatalf(report.Error("Unknown time unit %v", timeUnit))
-	return &IntConst{}
+	return &TimeConst{}
 }
 
-func BuildTimeConst(val string) *IntConst {
+func BuildTimeConst(val string) *TimeConst {
 	dateValueRegex := regexp.MustCompile(`^(\d{4})-?(\d{2})-?(\d{2})`)
 	dateValue := dateValueRegex.FindSubmatch([]byte(val))
 
@@ -236,11 +299,55 @@ func BuildTimeConst(val string) *IntConst {
 		}
 	}
 
-	return &IntConst{
+	return &TimeConst{
 		value: int(time.Date(year, time.Month(month), day, hour, minute, second, 0, time.UTC).Unix()),
 	}
 }
 
+// DurationConst is an amount of time as a number of seconds
+type DurationConst struct {
+	value int
+}
+
+// durationUnitsSeconds is the number of seconds of each unit of a duration literal.
+// Months and years are not units of durations because their length varies, e.g. 3 months ago is calendar-based.
+var durationUnitsSeconds = map[string]int{
+	"week":   7 * 24 * 60 * 60,
+	"day":    24 * 60 * 60,
+	"hour":   60 * 60,
+	"minute": 60,
+	"second": 1,
+}
+
+func BuildDurationConst(val string) *DurationConst {
+	durationRegex := regexp.MustCompile(`^([0-9]+)\s(week|day|hour|minute|second)`)
+	duration := durationRegex.FindStringSubmatch(val)
+	if duration == nil {
+		log.Fatalf(report.Error("Invalid duration %v", val))
+	}
+
+	amount, err := strconv.Atoi(duration[1])
+	if err != nil {
+		log.Fatalf(report.Error(err.Error()))
+	}
+
+	return &DurationConst{
+		value: amount * durationUnitsSeconds[duration[2]],
+	}
+}
+
+func (d *DurationConst) Kind() string {
+	return DURATION_CONST
+}
+
+func (thisDuration *DurationConst) equals(other Expr) bool {
+	if thisDuration.Kind() != other.Kind() {
+		return false
+	}
+
+	return thisDuration.value == other.(*DurationConst).value
+}
+
 type Variable struct {
 	ident string
 }
@@ -315,6 +422,24 @@ func BuildGreaterEqThanOp(lhs Expr, rhs Expr) *BinaryOp {
 	return BuildBinaryOp(lhs, greaterEqThanOperator(), rhs)
 }
 
+func BuildAddOp(lhs Expr, rhs Expr) *BinaryOp { return BuildBinaryOp(lhs, addOperator(), rhs) }
+func BuildSubOp(lhs Expr, rhs Expr) *BinaryOp { return BuildBinaryOp(lhs, subOperator(), rhs) }
+func BuildMulOp(lhs Expr, rhs Expr) *BinaryOp { return BuildBinaryOp(lhs, mulOperator(), rhs) }
+func BuildDivOp(lhs Expr, rhs Expr) *BinaryOp { return BuildBinaryOp(lhs, divOperator(), rhs) }
+func BuildModOp(lhs Expr, rhs Expr) *BinaryOp { return BuildBinaryOp(lhs, modOperator(), rhs) }
+
+func BuildMatchOp(lhs Expr, rhs Expr) *BinaryOp {
+	return BuildBinaryOp(lhs, matchOperator(), rhs)
+}
+
+func BuildInOp(lhs Expr, rhs Expr) *BinaryOp {
+	return BuildBinaryOp(lhs, inOperator(), rhs)
+}
+
+func BuildNotInOp(lhs Expr, rhs Expr) *BinaryOp {
+	return BuildBinaryOp(lhs, notInOperator(), rhs)
+}
+
 func BuildCmpOp(lhs Expr, op string, rhs Expr) Expr {
 	switch op {
 	case LESS_THAN_OP:
@@ -458,3 +583,104 @@ func (thisLambda *Lambda) equals(other Expr) bool {
 
 	return checkBody && checkParameters
 }
+
+type Let struct {
+	variable *Variable
+	value    Expr
+	body     Expr
+}
+
+func BuildLet(variable *Variable, value Expr, body Expr) *Let {
+	return &Let{variable, value, body}
+}
+
+func (l *Let) Kind() string {
+	return LET_CONST
+}
+
+func (thisLet *Let) equals(other Expr) bool {
+	if thisLet.Kind() != other.Kind() {
+		return false
+	}
+
+	otherLet := other.(*Let)
+	checkVariable := thisLet.variable.equals(otherLet.variable)
+	checkValue := thisLet.value.equals(otherLet.value)
+	checkBody := thisLet.body.equals(otherLet.body)
+
+	return checkVariable && checkValue && checkBody
+}
+
+type Conditional struct {
+	condition  Expr
+	thenBranch Expr
+	elseBranch Expr
+}
+
+func BuildConditional(condition Expr, thenBranch Expr, elseBranch Expr) *Conditional {
+	return &Conditional{condition, thenBranch, elseBranch}
+}
+
+func (c *Conditional) Kind() string {
+	return CONDITIONAL_CONST
+}
+
+func (thisConditional *Conditional) equals(other Expr) bool {
+	if thisConditional.Kind() != other.Kind() {
+		return false
+	}
+
+	otherConditional := other.(*Conditional)
+	checkCondition := thisConditional.condition.equals(otherConditional.condition)
+	checkThenBranch := thisConditional.thenBranch.equals(otherConditional.thenBranch)
+	checkElseBranch := thisConditional.elseBranch.equals(otherConditional.elseBranch)
+
+	return checkCondition && checkThenBranch && checkElseBranch
+}
+
+type FieldAccess struct {
+	expr  Expr
+	field string
+}
+
+func BuildFieldAccess(expr Expr, field string) *FieldAccess {
+	return &FieldAccess{expr, field}
+}
+
+func (fa *FieldAccess) Kind() string {
+	return FIELD_ACCESS_CONST
+}
+
+func (thisFieldAccess *FieldAccess) equals(other Expr) bool {
+	if thisFieldAccess.Kind() != other.Kind() {
+		return false
+	}
+
+	otherFieldAccess := other.(*FieldAccess)
+
+	return thisFieldAccess.field == otherFieldAccess.field && thisFieldAccess.expr.equals(otherFieldAccess.expr)
+}
+
+// Interpolation is a string literal with embedded expressions, e.g. "Hi @${author()}".
+// Its parts are the literal pieces of text and the embedded expressions, in order.
+type Interpolation struct {
+	parts []Expr
+}
+
+func BuildInterpolation(parts []Expr) *Interpolation {
+	return &Interpolation{parts}
+}
+
+func (i *Interpolation) Kind() string {
+	return INTERPOLATION_CONST
+}
+
+func (thisInterpolation *Interpolation) equals(other Expr) bool {
+	if thisInterpolation.Kind() != other.Kind() {
+		return false
+	}
+
+	otherInterpolation := other.(*Interpolation)
+
+	return EqualList(thisInterpolation.parts, otherInterpolation.parts)
+}
@@ -67,6 +67,62 @@ func TestGreaterEqThanOperator(t *testing.T) {
 	assert.Equal(t, wantVal, gotVal)
 }
 
+func TestAddOperator(t *testing.T) {
+	wantVal := &AddOp{}
+	gotVal := addOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestSubOperator(t *testing.T) {
+	wantVal := &SubOp{}
+	gotVal := subOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestMulOperator(t *testing.T) {
+	wantVal := &MulOp{}
+	gotVal := mulOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestDivOperator(t *testing.T) {
+	wantVal := &DivOp{}
+	gotVal := divOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestModOperator(t *testing.T) {
+	wantVal := &ModOp{}
+	gotVal := modOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestMatchOperator(t *testing.T) {
+	wantVal := &MatchOp{}
+	gotVal := matchOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestInOperator(t *testing.T) {
+	wantVal := &InOp{}
+	gotVal := inOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestNotInOperator(t *testing.T) {
+	wantVal := &NotInOp{}
+	gotVal := notInOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
 func TestGetOperator_WhenNotOp(t *testing.T) {
 	wantVal := NOT_OP
 	gotVal := notOperator().getOperator()
@@ -130,6 +186,62 @@ func TestGetOperator_WhenGreaterEqThanOp(t *testing.T) {
 	assert.Equal(t, wantVal, gotVal)
 }
 
+func TestGetOperator_WhenAddOp(t *testing.T) {
+	wantVal := ADD_OP
+	gotVal := addOperator().getOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestGetOperator_WhenSubOp(t *testing.T) {
+	wantVal := SUB_OP
+	gotVal := subOperator().getOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestGetOperator_WhenMulOp(t *testing.T) {
+	wantVal := MUL_OP
+	gotVal := mulOperator().getOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestGetOperator_WhenDivOp(t *testing.T) {
+	wantVal := DIV_OP
+	gotVal := divOperator().getOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestGetOperator_WhenModOp(t *testing.T) {
+	wantVal := MOD_OP
+	gotVal := modOperator().getOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestGetOperator_WhenMatchOp(t *testing.T) {
+	wantVal := MATCH_OP
+	gotVal := matchOperator().getOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestGetOperator_WhenInOp(t *testing.T) {
+	wantVal := IN_OP
+	gotVal := inOperator().getOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestGetOperator_WhenNotInOp(t *testing.T) {
+	wantVal := NOT_IN_OP
+	gotVal := notInOperator().getOperator()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
 func TestBuildBoolConst(t *testing.T) {
 	wantVal := &BoolConst{true}
 	gotVal := BuildBoolConst(true)
@@ -240,8 +352,9 @@ func TestBuildRelativeTimeConst_WhenTimeUnitIsYear(t *testing.T) {
 	val := "1 year ago"
 	timeValue := 1
 
-	wantVal := &IntConst{
-		value: int(now.AddDate(-timeValue, 0, 0).Unix()),
+	wantVal := &TimeConst{
+		value:    int(now.AddDate(-timeValue, 0, 0).Unix()),
+		relative: val,
 	}
 
 	gotVal := BuildRelativeTimeConst(val)
@@ -254,8 +367,9 @@ func TestBuildRelativeTimeConst_WhenTimeUnitIsMonth(t *testing.T) {
 	val := "1 month ago"
 	timeValue := 1
 
-	wantVal := &IntConst{
-		value: int(now.AddDate(0, -timeValue, 0).Unix()),
+	wantVal := &TimeConst{
+		value:    int(now.AddDate(0, -timeValue, 0).Unix()),
+		relative: val,
 	}
 	gotVal := BuildRelativeTimeConst(val)
 
@@ -267,8 +381,9 @@ func TestBuildRelativeTimeConst_WhenTimeUnitIsDay(t *testing.T) {
 	val := "1 day ago"
 	timeValue := 1
 
-	wantVal := &IntConst{
-		value: int(now.AddDate(0, 0, -timeValue).Unix()),
+	wantVal := &TimeConst{
+		value:    int(now.AddDate(0, 0, -timeValue).Unix()),
+		relative: val,
 	}
 
 	gotVal := BuildRelativeTimeConst(val)
@@ -281,8 +396,9 @@ func TestBuildRelativeTimeConst_WhenTimeUnitIsWeek(t *testing.T) {
 	val := "1 week ago"
 	timeValue := 1
 
-	wantVal := &IntConst{
-		value: int(now.Add(-(time.Hour * 24 * 7) * time.Duration(timeValue)).Unix()),
+	wantVal := &TimeConst{
+		value:    int(now.Add(-(time.Hour * 24 * 7) * time.Duration(timeValue)).Unix()),
+		relative: val,
 	}
 
 	gotVal := BuildRelativeTimeConst(val)
@@ -295,8 +411,9 @@ func TestBuildRelativeTimeConst_WhenTimeUnitIsHour(t *testing.T) {
 	val := "1 hour ago"
 	timeValue := 1
 
-	wantVal := &IntConst{
-		value: int(now.Add(-time.Hour * time.Duration(timeValue)).Unix()),
+	wantVal := &TimeConst{
+		value:    int(now.Add(-time.Hour * time.Duration(timeValue)).Unix()),
+		relative: val,
 	}
 
 	gotVal := BuildRelativeTimeConst(val)
@@ -309,8 +426,9 @@ func TestBuildRelativeTimeConst_WhenTimeUnitIsMinute(t *testing.T) {
 	val := "1 minute ago"
 	timeValue := 1
 
-	wantVal := &IntConst{
-		value: int(now.Add(-time.Minute * time.Duration(timeValue)).Unix()),
+	wantVal := &TimeConst{
+		value:    int(now.Add(-time.Minute * time.Duration(timeValue)).Unix()),
+		relative: val,
 	}
 
 	gotVal := BuildRelativeTimeConst(val)
@@ -321,7 +439,7 @@ func TestBuildRelativeTimeConst_WhenTimeUnitIsMinute(t *testing.T) {
 func TestBuildTimeConst(t *testing.T) {
 	val := "2019-10-12T07:50:52"
 
-	wantVal := &IntConst{
+	wantVal := &TimeConst{
 		value: int(time.Date(2019, time.Month(10), 12, 7, 50, 52, 0, time.UTC).Unix()),
 	}
 
@@ -330,6 +448,41 @@ func TestBuildTimeConst(t *testing.T) {
 	assert.Equal(t, wantVal, gotVal)
 }
 
+func TestTimeConstEquals(t *testing.T) {
+	timeConst := BuildTimeConst("2022-04-05")
+
+	assert.True(t, timeConst.equals(BuildTimeConst("20220405")))
+	assert.False(t, timeConst.equals(BuildTimeConst("2022-04-06")))
+	assert.False(t, timeConst.equals(BuildIntConst(timeConst.value)))
+}
+
+func TestBuildDurationConst(t *testing.T) {
+	tests := map[string]struct {
+		val     string
+		wantVal *DurationConst
+	}{
+		"seconds": {val: "30 seconds", wantVal: &DurationConst{value: 30}},
+		"minute":  {val: "1 minute", wantVal: &DurationConst{value: 60}},
+		"hours":   {val: "2 hours", wantVal: &DurationConst{value: 2 * 60 * 60}},
+		"days":    {val: "3 days", wantVal: &DurationConst{value: 3 * 24 * 60 * 60}},
+		"week":    {val: "1 week", wantVal: &DurationConst{value: 7 * 24 * 60 * 60}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.wantVal, BuildDurationConst(test.val))
+		})
+	}
+}
+
+func TestDurationConstEquals(t *testing.T) {
+	durationConst := BuildDurationConst("2 days")
+
+	assert.True(t, durationConst.equals(BuildDurationConst("48 hours")))
+	assert.False(t, durationConst.equals(BuildDurationConst("1 day")))
+	assert.False(t, durationConst.equals(BuildIntConst(durationConst.value)))
+}
+
 func TestBuildVariable(t *testing.T) {
 	wantVal := &Variable{"Test"}
 	gotVal := BuildVariable("Test")
@@ -470,6 +623,62 @@ func TestBuildGreaterEqThanOp(t *testing.T) {
 	assert.Equal(t, wantVal, gotVal)
 }
 
+func TestBuildAddOp(t *testing.T) {
+	wantVal := &BinaryOp{&IntConst{1}, &AddOp{}, &IntConst{2}}
+	gotVal := BuildAddOp(BuildIntConst(1), BuildIntConst(2))
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestBuildSubOp(t *testing.T) {
+	wantVal := &BinaryOp{&IntConst{1}, &SubOp{}, &IntConst{2}}
+	gotVal := BuildSubOp(BuildIntConst(1), BuildIntConst(2))
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestBuildMulOp(t *testing.T) {
+	wantVal := &BinaryOp{&IntConst{1}, &MulOp{}, &IntConst{2}}
+	gotVal := BuildMulOp(BuildIntConst(1), BuildIntConst(2))
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestBuildDivOp(t *testing.T) {
+	wantVal := &BinaryOp{&IntConst{1}, &DivOp{}, &IntConst{2}}
+	gotVal := BuildDivOp(BuildIntConst(1), BuildIntConst(2))
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestBuildModOp(t *testing.T) {
+	wantVal := &BinaryOp{&IntConst{1}, &ModOp{}, &IntConst{2}}
+	gotVal := BuildModOp(BuildIntConst(1), BuildIntConst(2))
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestBuildMatchOp(t *testing.T) {
+	wantVal := &BinaryOp{&StringConst{"feat: add"}, &MatchOp{}, &StringConst{"^feat"}}
+	gotVal := BuildMatchOp(BuildStringConst("feat: add"), BuildStringConst("^feat"))
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestBuildInOp(t *testing.T) {
+	wantVal := &BinaryOp{&StringConst{"john"}, &InOp{}, &Array{[]Expr{&StringConst{"john"}}}}
+	gotVal := BuildInOp(BuildStringConst("john"), BuildArray([]Expr{BuildStringConst("john")}))
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestBuildNotInOp(t *testing.T) {
+	wantVal := &BinaryOp{&StringConst{"john"}, &NotInOp{}, &Array{[]Expr{&StringConst{"john"}}}}
+	gotVal := BuildNotInOp(BuildStringConst("john"), BuildArray([]Expr{BuildStringConst("john")}))
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
 func TestBuildCmpOp_WhenOpIsLessThanOp(t *testing.T) {
 	wantVal := &BinaryOp{&IntConst{1}, &LessThanOp{}, &IntConst{2}}
 	gotVal := BuildCmpOp(BuildIntConst(1), LESS_THAN_OP, BuildIntConst(2))
@@ -699,3 +908,143 @@ func TestLambdaEquals_WhenEqual(t *testing.T) {
 
 	assert.True(t, lambda.equals(otherVal))
 }
+
+func TestBuildLet(t *testing.T) {
+	wantVal := &Let{&Variable{"x"}, &IntConst{1}, &Variable{"x"}}
+	gotVal := BuildLet(BuildVariable("x"), BuildIntConst(1), BuildVariable("x"))
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestLetKind(t *testing.T) {
+	wantVal := LET_CONST
+	gotVal := BuildLet(BuildVariable("x"), BuildIntConst(1), BuildVariable("x")).Kind()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestLetEquals_WhenDiffKinds(t *testing.T) {
+	let := BuildLet(BuildVariable("x"), BuildIntConst(1), BuildVariable("x"))
+	otherVal := BuildIntConst(1)
+
+	assert.False(t, let.equals(otherVal))
+}
+
+func TestLetEquals_WhenDiffValues(t *testing.T) {
+	let := BuildLet(BuildVariable("x"), BuildIntConst(1), BuildVariable("x"))
+	otherVal := BuildLet(BuildVariable("x"), BuildIntConst(2), BuildVariable("x"))
+
+	assert.False(t, let.equals(otherVal))
+}
+
+func TestLetEquals_WhenEqual(t *testing.T) {
+	let := BuildLet(BuildVariable("x"), BuildIntConst(1), BuildVariable("x"))
+	otherVal := BuildLet(BuildVariable("x"), BuildIntConst(1), BuildVariable("x"))
+
+	assert.True(t, let.equals(otherVal))
+}
+
+func TestBuildConditional(t *testing.T) {
+	wantVal := &Conditional{&BoolConst{true}, &IntConst{1}, &IntConst{2}}
+	gotVal := BuildConditional(BuildBoolConst(true), BuildIntConst(1), BuildIntConst(2))
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestConditionalKind(t *testing.T) {
+	wantVal := CONDITIONAL_CONST
+	gotVal := BuildConditional(BuildBoolConst(true), BuildIntConst(1), BuildIntConst(2)).Kind()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestConditionalEquals_WhenDiffKinds(t *testing.T) {
+	conditional := BuildConditional(BuildBoolConst(true), BuildIntConst(1), BuildIntConst(2))
+	otherVal := BuildIntConst(1)
+
+	assert.False(t, conditional.equals(otherVal))
+}
+
+func TestConditionalEquals_WhenDiffValues(t *testing.T) {
+	conditional := BuildConditional(BuildBoolConst(true), BuildIntConst(1), BuildIntConst(2))
+	otherVal := BuildConditional(BuildBoolConst(false), BuildIntConst(1), BuildIntConst(2))
+
+	assert.False(t, conditional.equals(otherVal))
+}
+
+func TestConditionalEquals_WhenEqual(t *testing.T) {
+	conditional := BuildConditional(BuildBoolConst(true), BuildIntConst(1), BuildIntConst(2))
+	otherVal := BuildConditional(BuildBoolConst(true), BuildIntConst(1), BuildIntConst(2))
+
+	assert.True(t, conditional.equals(otherVal))
+}
+
+func TestBuildFieldAccess(t *testing.T) {
+	wantVal := &FieldAccess{&Variable{"review"}, "user"}
+	gotVal := BuildFieldAccess(BuildVariable("review"), "user")
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestFieldAccessKind(t *testing.T) {
+	wantVal := FIELD_ACCESS_CONST
+	gotVal := BuildFieldAccess(BuildVariable("review"), "user").Kind()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestFieldAccessEquals_WhenDiffKinds(t *testing.T) {
+	fieldAccess := BuildFieldAccess(BuildVariable("review"), "user")
+	otherVal := BuildVariable("review")
+
+	assert.False(t, fieldAccess.equals(otherVal))
+}
+
+func TestFieldAccessEquals_WhenDiffFields(t *testing.T) {
+	fieldAccess := BuildFieldAccess(BuildVariable("review"), "user")
+	otherVal := BuildFieldAccess(BuildVariable("review"), "state")
+
+	assert.False(t, fieldAccess.equals(otherVal))
+}
+
+func TestFieldAccessEquals_WhenEqual(t *testing.T) {
+	fieldAccess := BuildFieldAccess(BuildVariable("review"), "user")
+	otherVal := BuildFieldAccess(BuildVariable("review"), "user")
+
+	assert.True(t, fieldAccess.equals(otherVal))
+}
+
+func TestBuildInterpolation(t *testing.T) {
+	wantVal := &Interpolation{[]Expr{&StringConst{"size is "}, &IntConst{1}}}
+	gotVal := BuildInterpolation([]Expr{BuildStringConst("size is "), BuildIntConst(1)})
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestInterpolationKind(t *testing.T) {
+	wantVal := INTERPOLATION_CONST
+	gotVal := BuildInterpolation([]Expr{}).Kind()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestInterpolationEquals_WhenDiffKinds(t *testing.T) {
+	interpolation := BuildInterpolation([]Expr{BuildStringConst("size")})
+	otherVal := BuildStringConst("size")
+
+	assert.False(t, interpolation.equals(otherVal))
+}
+
+func TestInterpolationEquals_WhenDiffParts(t *testing.T) {
+	interpolation := BuildInterpolation([]Expr{BuildStringConst("size is "), BuildIntConst(1)})
+	otherVal := BuildInterpolation([]Expr{BuildStringConst("size is "), BuildIntConst(2)})
+
+	assert.False(t, interpolation.equals(otherVal))
+}
+
+func TestInterpolationEquals_WhenEqual(t *testing.T) {
+	interpolation := BuildInterpolation([]Expr{BuildStringConst("size is "), BuildIntConst(1)})
+	otherVal := BuildInterpolation([]Expr{BuildStringConst("size is "), BuildIntConst(1)})
+
+	assert.True(t, interpolation.equals(otherVal))
+}
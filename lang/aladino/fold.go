@@ -0,0 +1,152 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+// Fold replaces the constant subexpressions of a well typed expression by their values,
+// e.g. 1 < 2 && $isDraft() becomes $isDraft().
+// Subexpressions whose evaluation fails, e.g. 1 / 0, are kept so that the error is raised when the expression is evaluated.
+// Pre-condition: the expression type checks
+func Fold(expr Expr) Expr {
+	return fold(expr, nil)
+}
+
+// fold is Fold keeping in the source map the span of each folded expression.
+func fold(expr Expr, s *sourceMap) Expr {
+	folded := foldExpr(expr, s)
+
+	if s != nil && folded != expr {
+		if span, ok := s.spans[expr]; ok {
+			s.spans[folded] = span
+		}
+	}
+
+	return folded
+}
+
+func foldExpr(expr Expr, s *sourceMap) Expr {
+	switch e := expr.(type) {
+	case *UnaryOp:
+		operand := fold(e.expr, s)
+		if isConst(operand) {
+			return evalConst(&UnaryOp{e.op, operand})
+		}
+
+		return &UnaryOp{e.op, operand}
+	case *BinaryOp:
+		return foldBinaryOp(e, s)
+	case *Conditional:
+		condition := fold(e.condition, s)
+		if boolConst, ok := condition.(*BoolConst); ok {
+			if boolConst.value {
+				return fold(e.thenBranch, s)
+			}
+			return fold(e.elseBranch, s)
+		}
+
+		return &Conditional{condition, fold(e.thenBranch, s), fold(e.elseBranch, s)}
+	case *FunctionCall:
+		return &FunctionCall{e.name, foldExprs(e.arguments, s)}
+	case *Lambda:
+		return &Lambda{e.parameters, fold(e.body, s)}
+	case *Array:
+		return &Array{foldExprs(e.elems, s)}
+	case *Let:
+		return &Let{e.variable, fold(e.value, s), fold(e.body, s)}
+	case *FieldAccess:
+		return &FieldAccess{fold(e.expr, s), e.field}
+	case *Interpolation:
+		parts := foldExprs(e.parts, s)
+		for _, part := range parts {
+			if !isConst(part) {
+				return &Interpolation{parts}
+			}
+		}
+
+		return evalConst(&Interpolation{parts})
+	}
+
+	return expr
+}
+
+func foldBinaryOp(b *BinaryOp, s *sourceMap) Expr {
+	lhs := fold(b.lhs, s)
+	rhs := fold(b.rhs, s)
+
+	// The left operand of && and || decides whether the right operand is evaluated.
+	// A constant right operand that does not change the result is dropped, e.g. $isDraft() && true.
+	switch b.op.getOperator() {
+	case AND_OP:
+		if lhsBool, ok := lhs.(*BoolConst); ok {
+			if !lhsBool.value {
+				return lhs
+			}
+			return rhs
+		}
+
+		if rhsBool, ok := rhs.(*BoolConst); ok && rhsBool.value {
+			return lhs
+		}
+	case OR_OP:
+		if lhsBool, ok := lhs.(*BoolConst); ok {
+			if lhsBool.value {
+				return lhs
+			}
+			return rhs
+		}
+
+		if rhsBool, ok := rhs.(*BoolConst); ok && !rhsBool.value {
+			return lhs
+		}
+	}
+
+	if isConst(lhs) && isConst(rhs) {
+		return evalConst(&BinaryOp{lhs, b.op, rhs})
+	}
+
+	return &BinaryOp{lhs, b.op, rhs}
+}
+
+func foldExprs(exprs []Expr, s *sourceMap) []Expr {
+	foldedExprs := make([]Expr, len(exprs))
+	for i, expr := range exprs {
+		foldedExprs[i] = fold(expr, s)
+	}
+
+	return foldedExprs
+}
+
+func isConst(expr Expr) bool {
+	switch expr.(type) {
+	case *BoolConst, *IntConst, *StringConst, *TimeConst, *DurationConst:
+		return true
+	}
+
+	return false
+}
+
+// evalConst replaces an expression whose operands are constants by its value.
+// The expression is kept when its evaluation fails or its value is not a constant, e.g. an array.
+func evalConst(expr Expr) Expr {
+	// Constants are evaluated without an environment.
+	value, err := expr.Eval(nil)
+	if err != nil {
+		return expr
+	}
+
+	switch val := value.(type) {
+	case *BoolValue:
+		return BuildBoolConst(val.Val)
+	case *IntValue:
+		return BuildIntConst(val.Val)
+	case *StringValue:
+		return BuildStringConst(val.Val)
+	case *TimeValue:
+		return &TimeConst{value: val.Val}
+	case *DurationValue:
+		return &DurationConst{value: val.Val}
+	}
+
+	return expr
+}
@@ -0,0 +1,62 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"constant":                {input: "true", want: "true"},
+		"arithmetic":              {input: "1 + 2 * 3", want: "7"},
+		"comparison":              {input: "1 < 2", want: "true"},
+		"negation":                {input: "!(1 == 2)", want: "true"},
+		"string concatenation":    {input: `"hello " + "world"`, want: `"hello world"`},
+		"duration":                {input: "1 day + 12 hours", want: "36 hours"},
+		"true and":                {input: "1 < 2 && $isDraft()", want: "$isDraft()"},
+		"false and":               {input: "1 > 2 && $isDraft()", want: "false"},
+		"and true":                {input: "$isDraft() && 1 < 2", want: "$isDraft()"},
+		"true or":                 {input: "1 < 2 || $isDraft()", want: "true"},
+		"or false":                {input: "$isDraft() || 1 > 2", want: "$isDraft()"},
+		"and false is evaluated":  {input: "$isDraft() && false", want: "$isDraft() && false"},
+		"conditional":             {input: `if 1 < 2 then "small" else $title()`, want: `"small"`},
+		"function call arguments": {input: `$returnStr("a" + "b")`, want: `$returnStr("ab")`},
+		"array elements":          {input: "[1 + 1, $size()]", want: "[2, $size()]"},
+		"lambda body":             {input: "($x: Int => $x > 1 + 1)", want: "($x: Int => $x > 2)"},
+		"interpolation":           {input: `"size ${1 + 1}"`, want: `"size 2"`},
+		"interpolation of call":   {input: `"size ${$size() + 1}"`, want: `"size ${$size() + 1}"`},
+		"division by zero":        {input: "$size() > 1 / 0", want: "$size() > 1 / 0"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			assert.Equal(t, test.want, Print(Fold(expr)))
+		})
+	}
+}
+
+func TestFold_KeepsSpans(t *testing.T) {
+	expr, exprSourceMap, err := parse("$size() > 1 + 1")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	folded := fold(expr, exprSourceMap)
+
+	wantSpan := exprSourceMap.spans[expr.(*BinaryOp).rhs]
+
+	assert.Equal(t, wantSpan, exprSourceMap.spans[folded.(*BinaryOp).rhs])
+}
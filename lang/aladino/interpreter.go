@@ -18,6 +18,49 @@ import (
 
 type Interpreter struct {
 	Env Env
+	// specs caches the specs of the rules and actions so that each spec is parsed once,
+	// even when it is evaluated by several workflows.
+	specs map[string]*spec
+}
+
+// spec is the AST of a spec together with its source map.
+type spec struct {
+	expr      Expr
+	sourceMap *sourceMap
+	// folded tells if the constant subexpressions of the AST were folded, which is done once the spec type checks.
+	folded bool
+}
+
+// parseSpec parses the spec, or returns its cached AST if it was already parsed.
+func (i *Interpreter) parseSpec(input string) (*spec, error) {
+	if parsedSpec, ok := i.specs[input]; ok {
+		return parsedSpec, nil
+	}
+
+	expr, exprSourceMap, err := parse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.specs == nil {
+		i.specs = make(map[string]*spec)
+	}
+
+	parsedSpec := &spec{expr: expr, sourceMap: exprSourceMap}
+	i.specs[input] = parsedSpec
+
+	return parsedSpec, nil
+}
+
+// fold folds the constant subexpressions of the spec.
+// Pre-condition: the spec type checks
+func (s *spec) fold() {
+	if s.folded {
+		return
+	}
+
+	s.expr = fold(s.expr, s.sourceMap)
+	s.folded = true
 }
 
 func execLog(val string) {
@@ -28,16 +71,17 @@ func execLogf(format string, a ...interface{}) {
 	log.Println(fmtio.Sprintf("aladino", format, a...))
 }
 
-func buildGroupAST(typeOf engine.GroupType, expr, paramExpr, whereExpr string) (Expr, error) {
+func buildGroupAST(typeOf engine.GroupType, expr, paramExpr, whereExpr string) (Expr, *sourceMap, error) {
 	if typeOf == engine.GroupTypeFilter {
-		whereExprAST, err := Parse(whereExpr)
+		whereExprAST, whereSourceMap, err := parse(whereExpr)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		return BuildFilter(paramExpr, whereExprAST)
+		filterAST, err := BuildFilter(paramExpr, whereExprAST)
+		return filterAST, whereSourceMap, err
 	} else {
-		return Parse(expr)
+		return parse(expr)
 	}
 }
 
@@ -48,27 +92,138 @@ func evalGroup(env Env, expr Expr) (Value, error) {
 	}
 
 	if exprType.Kind() != ARRAY_TYPE && exprType.Kind() != ARRAY_OF_TYPE {
-		return nil, fmt.Errorf("expression is not a valid group")
+		return nil, exprErrorf(expr, "expression is not a valid group")
 	}
 
 	return Eval(env, expr)
 }
 
 func (i *Interpreter) ProcessGroup(groupName string, kind engine.GroupKind, typeOf engine.GroupType, expr, paramExpr, whereExpr string) error {
-	exprAST, err := buildGroupAST(typeOf, expr, paramExpr, whereExpr)
+	exprAST, exprSourceMap, err := buildGroupAST(typeOf, expr, paramExpr, whereExpr)
 	if err != nil {
 		return fmt.Errorf("ProcessGroup:buildGroupAST: %v", err)
 	}
 
 	value, err := evalGroup(i.Env, exprAST)
 	if err != nil {
-		return fmt.Errorf("ProcessGroup:evalGroup %v", err)
+		return fmt.Errorf("ProcessGroup:evalGroup %v", exprSourceMap.locate(err))
 	}
 
 	i.Env.GetRegisterMap()[groupName] = value
 	return nil
 }
 
+// CallGraph finds the functions of the reviewpad file each function calls.
+func (i *Interpreter) CallGraph(functions []engine.PadFunction) engine.CallGraph {
+	return callGraph(functions)
+}
+
+// callGraph finds in the AST of each function the calls to the functions of the reviewpad file,
+// including the calls in string interpolations, e.g. "${isSmall(10)}".
+// A function whose spec does not parse calls no functions, its parse error is reported when it is processed.
+func callGraph(functions []engine.PadFunction) engine.CallGraph {
+	isFunction := make(map[string]bool, len(functions))
+	for _, function := range functions {
+		isFunction[function.Name] = true
+	}
+
+	graph := make(engine.CallGraph, len(functions))
+	for _, function := range functions {
+		body, _, err := parse(function.Spec)
+		if err != nil {
+			continue
+		}
+
+		calls := make([]string, 0)
+		called := make(map[string]bool)
+		walk(body, func(expr Expr) {
+			fc, ok := expr.(*FunctionCall)
+			if !ok || !isFunction[fc.name.ident] || called[fc.name.ident] {
+				return
+			}
+
+			called[fc.name.ident] = true
+			calls = append(calls, fc.name.ident)
+		})
+
+		graph[function.Name] = calls
+	}
+
+	return graph
+}
+
+// buildFunction type checks the body of a function defined in the reviewpad.yml file
+// and builds the built-in that evaluates it with the arguments bound to its parameters.
+func buildFunction(env Env, name string, parameters []engine.PadFunctionParameter, body Expr, bodySourceMap *sourceMap) (*BuiltInFunction, error) {
+	functionType, err := typeinferFunction(NewTypeEnv(env), parameters, body)
+	if err != nil {
+		return nil, bodySourceMap.locate(err)
+	}
+
+	return &BuiltInFunction{
+		Type: functionType,
+		Code: func(e Env, args []Value) (Value, error) {
+			bindings := make(map[string]Value, len(parameters))
+			for i, parameter := range parameters {
+				bindings[parameter.Name] = args[i]
+			}
+
+			value, err := evalWithBindings(e, bindings, body)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", name, bodySourceMap.locate(err))
+			}
+
+			return value, nil
+		},
+		// The built-ins called in the body check the kind of the target entity themselves.
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}, nil
+}
+
+// typeinferFunction infers the type of a function defined in the reviewpad.yml file from the types of its parameters.
+func typeinferFunction(env TypeEnv, parameters []engine.PadFunctionParameter, body Expr) (*FunctionType, error) {
+	bindings := make(map[string]Type, len(parameters))
+	paramsType := make([]Type, len(parameters))
+	for i, parameter := range parameters {
+		paramType := ParseType(parameter.Type)
+		if paramType == nil {
+			return nil, fmt.Errorf("unknown type %v of parameter %v", parameter.Type, parameter.Name)
+		}
+
+		bindings[parameter.Name] = paramType
+		paramsType[i] = paramType
+	}
+
+	returnType, err := typeinferWithBindings(env, bindings, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return BuildFunctionType(paramsType, returnType), nil
+}
+
+func (i *Interpreter) ProcessFunction(name string, parameters []engine.PadFunctionParameter, spec string) error {
+	builtIns := i.Env.GetBuiltIns()
+	_, isFunction := builtIns.Functions[name]
+	_, isAction := builtIns.Actions[name]
+	if isFunction || isAction {
+		return fmt.Errorf("ProcessFunction: %v is already a built-in", name)
+	}
+
+	bodyAST, bodySourceMap, err := parse(spec)
+	if err != nil {
+		return fmt.Errorf("ProcessFunction:parse: %v", err)
+	}
+
+	function, err := buildFunction(i.Env, name, parameters, bodyAST, bodySourceMap)
+	if err != nil {
+		return fmt.Errorf("ProcessFunction:buildFunction: %w", err)
+	}
+
+	builtIns.Functions[name] = function
+	return nil
+}
+
 func BuildInternalLabelID(id string) string {
 	return fmt.Sprintf("@label:%v", id)
 }
@@ -92,30 +247,52 @@ func (i *Interpreter) ProcessRule(name, spec string) error {
 }
 
 func EvalExpr(env Env, kind, expr string) (bool, error) {
-	exprAST, err := Parse(expr)
+	exprAST, exprSourceMap, err := parse(expr)
 	if err != nil {
 		return false, err
 	}
 
-	exprType, err := TypeInference(env, exprAST)
+	return evalSpec(env, expr, &spec{expr: exprAST, sourceMap: exprSourceMap})
+}
+
+func evalSpec(env Env, expr string, exprSpec *spec) (bool, error) {
+	exprType, err := TypeInference(env, exprSpec.expr)
 	if err != nil {
-		return false, err
+		return false, exprSpec.sourceMap.locate(err)
 	}
 
 	if exprType.Kind() != BOOL_TYPE {
-		return false, fmt.Errorf("expression %v is not a condition", expr)
+		return false, exprSpec.sourceMap.locate(exprErrorf(exprSpec.expr, "expression %v is not a condition", expr))
 	}
 
-	return EvalCondition(env, exprAST)
+	exprSpec.fold()
+
+	isTrue, err := EvalCondition(env, exprSpec.expr)
+	if err != nil {
+		return false, exprSpec.sourceMap.locate(err)
+	}
+
+	return isTrue, nil
 }
 
 func (i *Interpreter) EvalExpr(kind, expr string) (bool, error) {
-	return EvalExpr(i.Env, kind, expr)
+	exprSpec, err := i.parseSpec(expr)
+	if err != nil {
+		return false, err
+	}
+
+	return evalSpec(i.Env, expr, exprSpec)
 }
 
 func (i *Interpreter) ExecProgram(program *engine.Program) (engine.ExitStatus, error) {
 	execLog("executing program")
 
+	// By now, the rules were evaluated so the stats cover every call to the pure built-ins of the run.
+	defer func() {
+		hits, misses := i.Env.GetMemo().Stats()
+		execLogf("built-ins cache: %v hits, %v misses", hits, misses)
+	}()
+
 	for _, statement := range program.GetProgramStatements() {
 		err := i.ExecStatement(statement)
 		if err != nil {
@@ -136,20 +313,23 @@ func (i *Interpreter) ExecProgram(program *engine.Program) (engine.ExitStatus, e
 
 func (i *Interpreter) ExecStatement(statement *engine.Statement) error {
 	statRaw := statement.GetStatementCode()
-	statAST, err := Parse(statRaw)
+	statSpec, err := i.parseSpec(statRaw)
 	if err != nil {
 		return err
 	}
 
-	execStatAST, err := TypeCheckExec(i.Env, statAST)
+	_, err = TypeCheckExec(i.Env, statSpec.expr)
 	if err != nil {
-		return err
+		return statSpec.sourceMap.locate(err)
 	}
 
+	statSpec.fold()
+
 	if !i.Env.GetDryRun() {
-		err = execStatAST.exec(i.Env)
+		// Folding the arguments of the action keeps the call to the action.
+		err = statSpec.expr.(*FunctionCall).exec(i.Env)
 		if err != nil {
-			return err
+			return statSpec.sourceMap.locate(err)
 		}
 	}
 
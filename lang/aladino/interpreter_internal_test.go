@@ -23,7 +23,7 @@ import (
 func TestBuildGroupAST_WhenGroupTypeFilterIsSetAndParseFails(t *testing.T) {
 	groupName := "senior-developers"
 
-	gotExpr, err := buildGroupAST(
+	gotExpr, _, err := buildGroupAST(
 		engine.GroupTypeFilter,
 		fmt.Sprintf("$group(\"%v\")", groupName),
 		"dev",
@@ -31,13 +31,17 @@ func TestBuildGroupAST_WhenGroupTypeFilterIsSetAndParseFails(t *testing.T) {
 	)
 
 	assert.Nil(t, gotExpr)
-	assert.EqualError(t, err, "parse error: failed to build AST on input $hasFileExtensions(")
+	assert.EqualError(t, err, `parse error: unexpected end of input
+ --> line 1, column 20
+  |
+1 | $hasFileExtensions(
+  |                    ^`)
 }
 
 func TestBuildGroupAST_WhenGroupTypeFilterIsSet(t *testing.T) {
 	groupName := "senior-developers"
 
-	gotExpr, err := buildGroupAST(
+	gotExpr, _, err := buildGroupAST(
 		engine.GroupTypeFilter,
 		fmt.Sprintf("$group(\"%v\")", groupName),
 		"dev",
@@ -70,7 +74,7 @@ func TestBuildGroupAST_WhenGroupTypeFilterIsSet(t *testing.T) {
 func TestBuildGroupAST_WhenGroupTypeFilterIsNotSet(t *testing.T) {
 	devName := "jane"
 
-	gotExpr, err := buildGroupAST(
+	gotExpr, _, err := buildGroupAST(
 		engine.GroupTypeStatic,
 		fmt.Sprintf("[\"%v\"]", devName),
 		"",
@@ -158,7 +162,11 @@ func TestProcessGroup_WhenBuildGroupASTFails(t *testing.T) {
 		"",
 	)
 
-	assert.EqualError(t, err, fmt.Sprintf("ProcessGroup:buildGroupAST: parse error: failed to build AST on input %v", errExpr))
+	assert.EqualError(t, err, `ProcessGroup:buildGroupAST: parse error: unexpected end of input
+ --> line 1, column 8
+  |
+1 | $group(
+  |        ^`)
 }
 
 func TestProcessGroup_WhenEvalGroupFails(t *testing.T) {
@@ -180,7 +188,11 @@ func TestProcessGroup_WhenEvalGroupFails(t *testing.T) {
 		"",
 	)
 
-	assert.EqualError(t, err, "ProcessGroup:evalGroup expression is not a valid group")
+	assert.EqualError(t, err, `ProcessGroup:evalGroup expression is not a valid group
+ --> line 1, column 1
+  |
+1 | true
+  | ^^^^`)
 }
 
 func TestProcessGroup_WhenGroupTypeFilterIsNotSet(t *testing.T) {
@@ -212,6 +224,93 @@ func TestProcessGroup_WhenGroupTypeFilterIsNotSet(t *testing.T) {
 	assert.Equal(t, wantVal, gotVal)
 }
 
+func TestProcessFunction(t *testing.T) {
+	mockedEnv := MockDefaultEnv(t, nil, nil, MockBuiltIns(), nil)
+
+	mockedInterpreter := &Interpreter{
+		Env: mockedEnv,
+	}
+
+	parameters := []engine.PadFunctionParameter{
+		{Name: "name", Type: "String"},
+		{Name: "size", Type: "Int"},
+	}
+	err := mockedInterpreter.ProcessFunction("isSmall", parameters, `$returnStr($name) == "small" && $size < 10`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, BuildFunctionType([]Type{BuildStringType(), BuildIntType()}, BuildBoolType()), mockedEnv.GetBuiltIns().Functions["isSmall"].Type)
+
+	gotVal, err := EvalExpr(mockedEnv, "", `$isSmall("small", 5) && !$isSmall("small", 20)`)
+
+	assert.Nil(t, err)
+	assert.True(t, gotVal)
+	assert.Empty(t, mockedEnv.GetRegisterMap())
+}
+
+func TestProcessFunction_WhenNameIsABuiltIn(t *testing.T) {
+	mockedEnv := MockDefaultEnv(t, nil, nil, MockBuiltIns(), nil)
+
+	mockedInterpreter := &Interpreter{
+		Env: mockedEnv,
+	}
+
+	err := mockedInterpreter.ProcessFunction("emptyAction", []engine.PadFunctionParameter{}, "true")
+
+	assert.EqualError(t, err, "ProcessFunction: emptyAction is already a built-in")
+}
+
+func TestProcessFunction_WhenParameterTypeIsUnknown(t *testing.T) {
+	mockedEnv := MockDefaultEnv(t, nil, nil, MockBuiltIns(), nil)
+
+	mockedInterpreter := &Interpreter{
+		Env: mockedEnv,
+	}
+
+	parameters := []engine.PadFunctionParameter{{Name: "size", Type: "Number"}}
+	err := mockedInterpreter.ProcessFunction("isSmall", parameters, "$size < 10")
+
+	assert.EqualError(t, err, "ProcessFunction:buildFunction: unknown type Number of parameter size")
+}
+
+func TestProcessFunction_WhenTypeInferenceFails(t *testing.T) {
+	mockedEnv := MockDefaultEnv(t, nil, nil, MockBuiltIns(), nil)
+
+	mockedInterpreter := &Interpreter{
+		Env: mockedEnv,
+	}
+
+	parameters := []engine.PadFunctionParameter{{Name: "name", Type: "String"}}
+	err := mockedInterpreter.ProcessFunction("isSmall", parameters, "$name < 10")
+
+	assert.EqualError(t, err, `ProcessFunction:buildFunction: type inference failed
+ --> line 1, column 1
+  |
+1 | $name < 10
+  | ^^^^^^^^^^`)
+	assert.NotContains(t, mockedEnv.GetBuiltIns().Functions, "isSmall")
+}
+
+func TestProcessFunction_WhenBodyEvalFails(t *testing.T) {
+	mockedEnv := MockDefaultEnv(t, nil, nil, MockBuiltIns(), nil)
+
+	mockedInterpreter := &Interpreter{
+		Env: mockedEnv,
+	}
+
+	parameters := []engine.PadFunctionParameter{{Name: "size", Type: "Int"}}
+	err := mockedInterpreter.ProcessFunction("half", parameters, "10 / $size")
+	assert.Nil(t, err)
+
+	gotVal, err := EvalExpr(mockedEnv, "", "$half(0) > 1")
+
+	assert.False(t, gotVal)
+	assert.EqualError(t, err, `half: eval: division by zero
+ --> line 1, column 6
+  |
+1 | 10 / $size
+  |      ^^^^^`)
+}
+
 func TestBuildInternalLabelID(t *testing.T) {
 	labelID := "label_id"
 
@@ -278,7 +377,11 @@ func TestEvalExpr_WhenParseFails(t *testing.T) {
 	gotVal, err := EvalExpr(mockedEnv, "", "1 ==")
 
 	assert.False(t, gotVal)
-	assert.EqualError(t, err, "parse error: failed to build AST on input 1 ==")
+	assert.EqualError(t, err, `parse error: unexpected end of input
+ --> line 1, column 5
+  |
+1 | 1 ==
+  |     ^`)
 }
 
 func TestEvalExpr_WhenTypeInferenceFails(t *testing.T) {
@@ -287,7 +390,11 @@ func TestEvalExpr_WhenTypeInferenceFails(t *testing.T) {
 	gotVal, err := EvalExpr(mockedEnv, "", "1 == \"a\"")
 
 	assert.False(t, gotVal)
-	assert.EqualError(t, err, "type inference failed")
+	assert.EqualError(t, err, `type inference failed
+ --> line 1, column 1
+  |
+1 | 1 == "a"
+  | ^^^^^^^^`)
 }
 
 func TestEvalExpr_WhenExprIsNotBoolType(t *testing.T) {
@@ -296,7 +403,11 @@ func TestEvalExpr_WhenExprIsNotBoolType(t *testing.T) {
 	gotVal, err := EvalExpr(mockedEnv, "", "1")
 
 	assert.False(t, gotVal)
-	assert.EqualError(t, err, "expression 1 is not a condition")
+	assert.EqualError(t, err, `expression 1 is not a condition
+ --> line 1, column 1
+  |
+1 | 1
+  | ^`)
 }
 
 func TestEvalExpr(t *testing.T) {
@@ -321,6 +432,61 @@ func TestEvalExpr_OnInterpreter(t *testing.T) {
 	assert.True(t, gotVal)
 }
 
+func TestEvalExpr_OnInterpreter_WhenSpecIsEvaluatedTwice(t *testing.T) {
+	mockedEnv := MockDefaultEnv(t, nil, nil, MockBuiltIns(), nil)
+
+	mockedInterpreter := &Interpreter{
+		Env: mockedEnv,
+	}
+
+	spec := "1 < 2 && $zeroConst() == 0"
+	for i := 0; i < 2; i++ {
+		gotVal, err := mockedInterpreter.EvalExpr("", spec)
+
+		assert.Nil(t, err)
+		assert.True(t, gotVal)
+	}
+
+	assert.Len(t, mockedInterpreter.specs, 1)
+	assert.True(t, mockedInterpreter.specs[spec].folded)
+	assert.Equal(t, "$zeroConst() == 0", Print(mockedInterpreter.specs[spec].expr))
+}
+
+func TestExecStatement_WhenStatementIsExecutedTwice(t *testing.T) {
+	var gotArgs [][]Value
+	builtIns := &BuiltIns{
+		Actions: map[string]*BuiltInAction{
+			"addLabel": {
+				Type: BuildFunctionType([]Type{BuildStringType()}, nil),
+				Code: func(e Env, args []Value) error {
+					gotArgs = append(gotArgs, args)
+					return nil
+				},
+				SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+			},
+		},
+	}
+
+	mockedEnv := MockDefaultEnv(t, nil, nil, builtIns, nil)
+
+	mockedInterpreter := &Interpreter{
+		Env: mockedEnv,
+	}
+
+	statCode := `$addLabel("size/" + "small")`
+	for i := 0; i < 2; i++ {
+		err := mockedInterpreter.ExecStatement(engine.BuildStatement(statCode))
+
+		assert.Nil(t, err)
+	}
+
+	wantArgs := [][]Value{{BuildStringValue("size/small")}, {BuildStringValue("size/small")}}
+
+	assert.Equal(t, wantArgs, gotArgs)
+	assert.Len(t, mockedInterpreter.specs, 1)
+	assert.Equal(t, `$addLabel("size/small")`, Print(mockedInterpreter.specs[statCode].expr))
+}
+
 func TestExecProgram_WhenExecStatementFails(t *testing.T) {
 	mockedEnv := MockDefaultEnv(t, nil, nil, MockBuiltIns(), nil)
 
@@ -335,7 +501,11 @@ func TestExecProgram_WhenExecStatementFails(t *testing.T) {
 	exitStatus, err := mockedInterpreter.ExecProgram(program)
 
 	assert.Equal(t, engine.ExitStatusFailure, exitStatus)
-	assert.EqualError(t, err, "no type for built-in action. Please check if the mode in the reviewpad.yml file supports it")
+	assert.EqualError(t, err, `no type for built-in action. Please check if the mode in the reviewpad.yml file supports it
+ --> line 1, column 1
+  |
+1 | $action()
+  | ^^^^^^^`)
 }
 
 func TestExecProgram(t *testing.T) {
@@ -403,7 +573,11 @@ func TestExecStatement_WhenParseFails(t *testing.T) {
 
 	err := mockedInterpreter.ExecStatement(statement)
 
-	assert.EqualError(t, err, "parse error: failed to build AST on input $addLabel(")
+	assert.EqualError(t, err, `parse error: unexpected end of input
+ --> line 1, column 11
+  |
+1 | $addLabel(
+  |           ^`)
 }
 
 func TestExecStatement_WhenTypeCheckExecFails(t *testing.T) {
@@ -429,7 +603,11 @@ func TestExecStatement_WhenTypeCheckExecFails(t *testing.T) {
 
 	err := mockedInterpreter.ExecStatement(statement)
 
-	assert.EqualError(t, err, "type inference failed: mismatch in arg types on addLabel")
+	assert.EqualError(t, err, `type inference failed: mismatch in arg types on addLabel
+ --> line 1, column 1
+  |
+1 | $addLabel(1)
+  | ^^^^^^^^^^^^`)
 }
 
 func TestExecStatement_WhenActionExecFails(t *testing.T) {
@@ -456,7 +634,11 @@ func TestExecStatement_WhenActionExecFails(t *testing.T) {
 
 	err := mockedInterpreter.ExecStatement(statement)
 
-	assert.EqualError(t, err, "exec: author not found. are you sure this is a built-in function?")
+	assert.EqualError(t, err, `exec: author not found. are you sure this is a built-in function?
+ --> line 1, column 1
+  |
+1 | $author()
+  | ^^^^^^^^^`)
 }
 
 func TestExecStatement(t *testing.T) {
@@ -748,3 +930,22 @@ func TestNewInterpreter(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, wantInterpreter, gotInterpreter)
 }
+
+func TestCallGraph(t *testing.T) {
+	functions := []engine.PadFunction{
+		{Name: "isReady", Spec: `$isSmall($size()) && "${isSmall(1)} ${isLarge(2)}" != "" && !$isDraft()`},
+		{Name: "isSmall", Spec: `$size < 10`},
+		{Name: "isLarge", Spec: `$any([1], ($n => $isSmall($n)))`},
+		{Name: "isInvalid", Spec: `$isSmall(`},
+	}
+
+	mockedInterpreter := &Interpreter{}
+
+	wantCallGraph := engine.CallGraph{
+		"isReady": {"isSmall", "isLarge"},
+		"isSmall": {},
+		"isLarge": {"isSmall"},
+	}
+
+	assert.Equal(t, wantCallGraph, mockedInterpreter.CallGraph(functions))
+}
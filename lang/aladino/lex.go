@@ -15,10 +15,20 @@ import (
 type AladinoLex struct {
 	input string
 	ast   Expr
+	// source is the whole input while input is what is left to lex.
+	source string
+	spans  map[Expr]Span
+	// lastSpan is the span of the last token returned to the parser.
+	lastSpan Span
+	// lastToken is the last token returned to the parser.
+	lastToken int
+	err       error
 }
 
 const EOF = 0
 
+var reIdentifier = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*`)
+
 type tokenDef struct {
 	regex *regexp.Regexp
 	kind  string
@@ -47,6 +57,16 @@ var tokens = []tokenDef{
 		kind:  "relativeTimestamp",
 		token: RELATIVETIMESTAMP,
 	},
+	{
+		// Examples:
+		// 2 days
+		// 1 hour
+		// 30 minutes
+		// Months and years are not durations because their length varies, so they are only in relative timestamps.
+		regex: regexp.MustCompile(`^[0-9]+\s(week(s?)|day(s?)|hour(s?)|minute(s?)|second(s?))\b`),
+		kind:  "duration",
+		token: DURATION,
+	},
 	{
 		regex: regexp.MustCompile(`^[0-9]*\.?[0-9]+([eE][-+]?[0-9]+)?`),
 		kind:  "number",
@@ -63,12 +83,42 @@ var tokens = []tokenDef{
 		token: FALSE,
 	},
 	{
-		regex: regexp.MustCompile(`^:\s?[a-zA-Z]*`),
+		regex: regexp.MustCompile(`^let\b`),
+		kind:  "keyword",
+		token: TK_LET,
+	},
+	{
+		regex: regexp.MustCompile(`^if\b`),
+		kind:  "keyword",
+		token: TK_IF,
+	},
+	{
+		regex: regexp.MustCompile(`^then\b`),
+		kind:  "keyword",
+		token: TK_THEN,
+	},
+	{
+		regex: regexp.MustCompile(`^else\b`),
+		kind:  "keyword",
+		token: TK_ELSE,
+	},
+	{
+		regex: regexp.MustCompile(`^in\b`),
+		kind:  "binop",
+		token: TK_IN,
+	},
+	{
+		regex: regexp.MustCompile(`^not\s+in\b`),
+		kind:  "binop",
+		token: TK_NOT_IN,
+	},
+	{
+		regex: regexp.MustCompile(`^:\s?(\[\])*[a-zA-Z]+`),
 		kind:  "type",
 		token: TK_TYPE,
 	},
 	{
-		regex: regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*`),
+		regex: reIdentifier,
 		kind:  "identifier",
 		token: IDENTIFIER,
 	},
@@ -87,6 +137,11 @@ var tokens = []tokenDef{
 		kind:  "binop",
 		token: TK_EQ,
 	},
+	{
+		regex: regexp.MustCompile(`^=~`),
+		kind:  "binop",
+		token: TK_MATCH,
+	},
 	{
 		regex: regexp.MustCompile(`^!=`),
 		kind:  "binop",
@@ -115,16 +170,43 @@ var tokens = []tokenDef{
 }
 
 func (l *AladinoLex) Lex(lval *AladinoSymType) int {
+	token := l.lex(lval)
+	l.lastSpan = lval.span
+	l.lastToken = token
+	return token
+}
+
+func (l *AladinoLex) lex(lval *AladinoSymType) int {
 	// fmt.Printf("lex: input: %v\n", l.input)
 	// Skip spaces.
 	for ; len(l.input) > 0 && isSpace(l.input[0]); l.input = l.input[1:] {
 	}
 
+	start := l.offset()
+
 	// Check if the input has ended.
 	if len(l.input) == 0 {
+		lval.span = Span{start, start}
 		return EOF
 	}
 
+	if l.input[0] == '"' {
+		if token, ok := l.lexString(lval); ok {
+			return token
+		}
+	}
+
+	// The keywords are only reserved where an expression or an operator is expected,
+	// so the names of variables and fields can be keywords, e.g. $in or $review.if.
+	if l.lastToken == '$' || l.lastToken == '.' {
+		if name := reIdentifier.FindString(l.input); name != "" {
+			l.input = l.input[len(name):]
+			lval.str = name
+			lval.span = Span{start, l.offset()}
+			return IDENTIFIER
+		}
+	}
+
 	// Check if one of the regular expressions matches.
 	for _, tokDef := range tokens {
 		str := tokDef.regex.FindString(l.input)
@@ -151,17 +233,276 @@ func (l *AladinoLex) Lex(lval *AladinoSymType) int {
 		}
 
 		l.input = l.input[len(str):]
+		lval.span = Span{start, l.offset()}
 		return tokDef.token
 	}
 
 	// Otherwise return the next letter.
 	ret := int(l.input[0])
 	l.input = l.input[1:]
+	lval.span = Span{start, l.offset()}
 	return ret
 }
 
+// lexString lexes a string literal with the placeholders ${...} of its embedded expressions, if any.
+// A placeholder whose expression starts with a function call may omit its $, e.g. "${author()}" is "${$author()}".
+// The sequence \${ stands for the text ${, as does a ${ that is not followed by an expression, e.g. "${{ github.sha }}".
+// It fails if the input does not start with a complete string literal.
+func (l *AladinoLex) lexString(lval *AladinoSymType) (int, bool) {
+	start := l.offset()
+	end := skipString(l.input, 0)
+	if end < 0 {
+		placeholderStart := unterminatedPlaceholder(l.input)
+		if placeholderStart < 0 {
+			return 0, false
+		}
+
+		return l.lexError(lval, "parse error: unterminated placeholder", Span{start + placeholderStart, start + placeholderStart + 2}), true
+	}
+
+	literal := l.input[1 : end-1]
+	if !strings.Contains(literal, "${") {
+		l.input = l.input[end:]
+		lval.str = literal
+		lval.span = Span{start, l.offset()}
+		return STRINGLITERAL, true
+	}
+
+	parts := make([]Expr, 0)
+	var text strings.Builder
+	for i := 0; i < len(literal); {
+		if strings.HasPrefix(literal[i:], `\${`) {
+			text.WriteString("${")
+			i += 3
+			continue
+		}
+
+		if !strings.HasPrefix(literal[i:], "${") {
+			text.WriteByte(literal[i])
+			i++
+			continue
+		}
+
+		placeholderEnd, ok := skipPlaceholderOrText(literal, i)
+		if !ok {
+			text.WriteString("${")
+			i += 2
+			continue
+		}
+
+		// exprStart is the position of the expression of the placeholder in the source.
+		exprStart := start + 1 + i + 2
+		expr, err := l.lexPlaceholder(literal[i+2:placeholderEnd-1], exprStart)
+		if err != nil {
+			l.err = err
+			return ILLEGAL, true
+		}
+		i = placeholderEnd
+
+		// The string literal of a placeholder is part of the text, e.g. "${"}"}" is "}".
+		if str, ok := expr.(*StringConst); ok {
+			text.WriteString(str.value)
+			continue
+		}
+
+		if text.Len() > 0 {
+			parts = append(parts, BuildStringConst(text.String()))
+			text.Reset()
+		}
+
+		parts = append(parts, expr)
+	}
+
+	l.input = l.input[end:]
+	lval.span = Span{start, l.offset()}
+
+	if len(parts) == 0 {
+		lval.str = text.String()
+		return STRINGLITERAL, true
+	}
+
+	if text.Len() > 0 {
+		parts = append(parts, BuildStringConst(text.String()))
+	}
+
+	lval.ast = BuildInterpolation(parts)
+	return INTERPOLATION, true
+}
+
+// lexError stops the parsing with an error found by the lexer.
+func (l *AladinoLex) lexError(lval *AladinoSymType, message string, span Span) int {
+	l.err = &Diagnostic{
+		Message: message,
+		Source:  l.source,
+		Span:    span,
+	}
+	lval.span = span
+	return ILLEGAL
+}
+
+var reShorthandCall = regexp.MustCompile(`^\s*([a-zA-Z][a-zA-Z0-9]*)\(`)
+
+// lexPlaceholder parses the expression of a placeholder that starts at the given position in the source.
+// The span of each node of the expression is recorded relative to the whole source.
+func (l *AladinoLex) lexPlaceholder(placeholder string, position int) (Expr, error) {
+	// The $ of the placeholder is the one of the function call.
+	isShorthand := false
+	if match := reShorthandCall.FindStringSubmatch(placeholder); match != nil && match[1] != "if" && match[1] != "let" {
+		isShorthand = true
+		placeholder = "$" + placeholder
+		position--
+	}
+
+	expr, exprSourceMap, err := parse(placeholder)
+	if err != nil {
+		diagnostic, ok := err.(*Diagnostic)
+		if !ok {
+			return nil, err
+		}
+
+		return nil, &Diagnostic{
+			Message: diagnostic.Message,
+			Source:  l.source,
+			Span:    Span{diagnostic.Span.Start + position, diagnostic.Span.End + position},
+		}
+	}
+
+	for node, span := range exprSourceMap.spans {
+		// The spans that start at the added $ start at the name of the function instead.
+		if isShorthand && span.Start == 0 {
+			span.Start++
+		}
+
+		l.spans[node] = Span{span.Start + position, span.End + position}
+	}
+
+	return expr, nil
+}
+
+// skipString returns the position right after the string literal that starts at position i of input.
+// It returns -1 if the string literal is not terminated.
+func skipString(input string, i int) int {
+	for i++; i < len(input); {
+		switch {
+		case input[i] == '"':
+			return i + 1
+		case strings.HasPrefix(input[i:], `\${`):
+			i += 3
+		case strings.HasPrefix(input[i:], "${"):
+			end, ok := skipPlaceholderOrText(input, i)
+			if !ok {
+				i += 2
+				continue
+			}
+
+			if end < 0 {
+				return -1
+			}
+
+			i = end
+		default:
+			i++
+		}
+	}
+
+	return -1
+}
+
+var reExpressionStart = regexp.MustCompile(`^\s*(\$|[a-zA-Z][a-zA-Z0-9]*\()`)
+
+// skipPlaceholderOrText returns the position right after the placeholder that starts with the ${ at position i of input,
+// which is -1 if the placeholder is not closed.
+// The ${ is text instead when what follows neither starts with a variable or a function call nor parses,
+// so that string literals written before placeholders existed keep their meaning, e.g. "use ${capture}".
+func skipPlaceholderOrText(input string, i int) (int, bool) {
+	end := skipPlaceholder(input, i+2)
+	if reExpressionStart.MatchString(input[i+2:]) {
+		return end, true
+	}
+
+	if end < 0 {
+		return -1, false
+	}
+
+	if _, _, err := parse(input[i+2 : end-1]); err != nil {
+		return -1, false
+	}
+
+	return end, true
+}
+
+// unterminatedPlaceholder returns the position of the placeholder that is not closed in the string literal that starts input.
+// It returns -1 if the string literal is not terminated for another reason.
+func unterminatedPlaceholder(input string) int {
+	for i := 1; i < len(input) && input[i] != '"'; {
+		switch {
+		case strings.HasPrefix(input[i:], `\${`):
+			i += 3
+		case strings.HasPrefix(input[i:], "${"):
+			end, ok := skipPlaceholderOrText(input, i)
+			switch {
+			case !ok:
+				i += 2
+			case end < 0:
+				return i
+			default:
+				i = end
+			}
+		default:
+			i++
+		}
+	}
+
+	return -1
+}
+
+// skipPlaceholder returns the position right after the } that closes the placeholder whose expression starts at position i of input.
+// It returns -1 if the placeholder is not closed.
+func skipPlaceholder(input string, i int) int {
+	depth := 1
+	for i < len(input) {
+		switch input[i] {
+		case '"':
+			i = skipString(input, i)
+			if i < 0 {
+				return -1
+			}
+			continue
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+		i++
+	}
+
+	return -1
+}
+
+// offset is the position in the source of the next character to lex.
+func (l *AladinoLex) offset() int {
+	return len(l.source) - len(l.input)
+}
+
 func (l *AladinoLex) Error(s string) {
-	fmt.Printf("syntax error on %s\n", l.input)
+	// Errors found by the lexer itself are more precise than the one of the parser.
+	if l.err != nil {
+		return
+	}
+
+	message := "parse error: unexpected end of input"
+	if l.lastSpan.Start < len(l.source) {
+		message = fmt.Sprintf("parse error: unexpected %q", l.source[l.lastSpan.Start:l.lastSpan.End])
+	}
+
+	l.err = &Diagnostic{
+		Message: message,
+		Source:  l.source,
+		Span:    l.lastSpan,
+	}
 }
 
 func isSpace(c byte) bool {
@@ -177,7 +518,20 @@ func ParseType(typeOf string) Type {
 		return BuildIntType()
 	case "Bool":
 		return BuildBoolType()
+	case "Time":
+		return BuildTimeType()
+	case "Duration":
+		return BuildDurationType()
 	default:
+		if strings.HasPrefix(typeOf, "[]") {
+			elemType := ParseType(strings.TrimPrefix(typeOf, "[]"))
+			if elemType == nil {
+				return nil
+			}
+
+			return BuildArrayOfType(elemType)
+		}
+
 		// FIXME: throw error
 		return nil
 	}
@@ -0,0 +1,273 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"fmt"
+
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/utils/fmtio"
+)
+
+// LintError is an error found by Lint in a spec of a reviewpad file.
+type LintError struct {
+	// Path is the path of the spec in the reviewpad file, e.g. rules[0].spec.
+	Path string
+	Err  error
+}
+
+func (e *LintError) Error() string {
+	return fmtio.Sprintf("lint", "%v: %v", e.Path, e.Err)
+}
+
+func (e *LintError) Unwrap() error {
+	return e.Err
+}
+
+func lintError(path string, err error) error {
+	return &LintError{Path: path, Err: err}
+}
+
+func lintLog(format string, a ...interface{}) {
+	fmtio.LogPrintln("lint", format, a...)
+}
+
+// Lint type checks the specs and the actions of a reviewpad file against the built-ins.
+// Nothing is evaluated so the file is checked without a target.
+// Validations:
+// - Every function, group, rule and pipeline spec is well typed
+// - The regexes given as string literals to built-ins are valid
+// - Groups are arrays and rules, pipeline triggers and stage conditions are conditions
+// - Every action is a well typed call to a built-in action
+// - Every action of a workflow supports the kinds of entities the workflow runs on
+// Rules that are always true or always false are reported as warnings.
+func Lint(file *engine.ReviewpadFile, builtIns *BuiltIns) error {
+	typeEnv := BuildTypeEnv(builtIns)
+
+	// The functions are type checked after the functions they call so that their types are known.
+	functions, err := engine.SortFunctions(file.Functions, callGraph(file.Functions))
+	if err != nil {
+		return fmtio.Errorf("lint", "%v", err)
+	}
+
+	functionsIndex := make(map[string]int)
+	for i, function := range file.Functions {
+		functionsIndex[function.Name] = i
+	}
+
+	for _, function := range functions {
+		path := fmt.Sprintf("functions[%v].spec", functionsIndex[function.Name])
+
+		if _, isBuiltIn := typeEnv[function.Name]; isBuiltIn {
+			return lintError(path, fmt.Errorf("%v is already a built-in", function.Name))
+		}
+
+		body, bodySourceMap, err := parse(function.Spec)
+		if err != nil {
+			return lintError(path, err)
+		}
+
+		functionType, err := typeinferFunction(typeEnv, function.Parameters, body)
+		if err != nil {
+			return lintError(path, bodySourceMap.locate(err))
+		}
+
+		if err := lintRegexes(builtIns, body); err != nil {
+			return lintError(path, bodySourceMap.locate(err))
+		}
+
+		typeEnv[function.Name] = functionType
+	}
+
+	for i, group := range file.Groups {
+		path := fmt.Sprintf("groups[%v].spec", i)
+		if engine.GroupType(group.Type) == engine.GroupTypeFilter {
+			path = fmt.Sprintf("groups[%v].where", i)
+		}
+
+		if err := lintGroup(typeEnv, builtIns, group); err != nil {
+			return lintError(path, err)
+		}
+	}
+
+	for i, rule := range file.Rules {
+		ruleExpr, err := lintCondition(typeEnv, builtIns, rule.Spec)
+		if err != nil {
+			return lintError(fmt.Sprintf("rules[%v].spec", i), err)
+		}
+
+		if value, isConstant := conditionValue(ruleExpr); isConstant {
+			lintLog("warning: rule %v is always %v", rule.Name, value)
+		}
+	}
+
+	for i, workflow := range file.Workflows {
+		for j, rule := range workflow.Rules {
+			for k, action := range rule.ExtraActions {
+				if err := lintAction(typeEnv, builtIns, workflow.On, action); err != nil {
+					return lintError(fmt.Sprintf("workflows[%v].if[%v].extra-actions[%v]", i, j, k), err)
+				}
+			}
+		}
+
+		for j, action := range workflow.Actions {
+			if err := lintAction(typeEnv, builtIns, workflow.On, action); err != nil {
+				return lintError(fmt.Sprintf("workflows[%v].then[%v]", i, j), err)
+			}
+		}
+
+		for j, action := range workflow.ElseActions {
+			if err := lintAction(typeEnv, builtIns, workflow.On, action); err != nil {
+				return lintError(fmt.Sprintf("workflows[%v].else[%v]", i, j), err)
+			}
+		}
+	}
+
+	for i, pipeline := range file.Pipelines {
+		if pipeline.Trigger != "" {
+			if _, err := lintCondition(typeEnv, builtIns, pipeline.Trigger); err != nil {
+				return lintError(fmt.Sprintf("pipelines[%v].trigger", i), err)
+			}
+		}
+
+		for j, stage := range pipeline.Stages {
+			// Pipelines run on any kind of entity so only the types of their actions are checked.
+			for k, action := range stage.Actions {
+				if err := lintAction(typeEnv, builtIns, nil, action); err != nil {
+					return lintError(fmt.Sprintf("pipelines[%v].stages[%v].actions[%v]", i, j, k), err)
+				}
+			}
+
+			if stage.Until != "" {
+				if _, err := lintCondition(typeEnv, builtIns, stage.Until); err != nil {
+					return lintError(fmt.Sprintf("pipelines[%v].stages[%v].until", i, j), err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func lintGroup(env TypeEnv, builtIns *BuiltIns, group engine.PadGroup) error {
+	expr, exprSourceMap, err := buildGroupAST(engine.GroupType(group.Type), group.Spec, group.Param, group.Where)
+	if err != nil {
+		return err
+	}
+
+	exprType, err := expr.typeinfer(env)
+	if err != nil {
+		return exprSourceMap.locate(err)
+	}
+
+	if exprType.Kind() != ARRAY_TYPE && exprType.Kind() != ARRAY_OF_TYPE {
+		return exprSourceMap.locate(exprErrorf(expr, "expression is not a valid group"))
+	}
+
+	if err := lintRegexes(builtIns, expr); err != nil {
+		return exprSourceMap.locate(err)
+	}
+
+	return nil
+}
+
+func lintCondition(env TypeEnv, builtIns *BuiltIns, spec string) (Expr, error) {
+	expr, exprSourceMap, err := parse(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	exprType, err := expr.typeinfer(env)
+	if err != nil {
+		return nil, exprSourceMap.locate(err)
+	}
+
+	if exprType.Kind() != BOOL_TYPE {
+		return nil, exprSourceMap.locate(exprErrorf(expr, "expression %v is not a condition", spec))
+	}
+
+	if err := lintRegexes(builtIns, expr); err != nil {
+		return nil, exprSourceMap.locate(err)
+	}
+
+	return expr, nil
+}
+
+// lintAction checks that the action is a call to a built-in action that supports every given kind of entity.
+func lintAction(env TypeEnv, builtIns *BuiltIns, kinds []handler.TargetEntityKind, spec string) error {
+	expr, exprSourceMap, err := parse(spec)
+	if err != nil {
+		return err
+	}
+
+	fc, ok := expr.(*FunctionCall)
+	if !ok {
+		return exprSourceMap.locate(exprErrorf(expr, "%v is not an action", spec))
+	}
+
+	action, ok := builtIns.Actions[fc.name.ident]
+	if !ok {
+		return exprSourceMap.locate(exprErrorf(fc.name, "%v is not a built-in action", fc.name.ident))
+	}
+
+	if _, err := fc.typeinfer(env); err != nil {
+		return exprSourceMap.locate(err)
+	}
+
+	if err := lintRegexes(builtIns, fc); err != nil {
+		return exprSourceMap.locate(err)
+	}
+
+	for _, kind := range kinds {
+		if !isKindSupported(action.SupportedKinds, kind) {
+			return exprSourceMap.locate(exprErrorf(fc.name, "action %v does not support %v", fc.name.ident, kind))
+		}
+	}
+
+	return nil
+}
+
+// lintRegexes checks the regexes given as string literals to the calls of built-ins, e.g. $matches($title(), "^feat").
+// Pre-condition: the expression type checks
+func lintRegexes(builtIns *BuiltIns, expr Expr) error {
+	var err error
+
+	walk(expr, func(e Expr) {
+		fc, ok := e.(*FunctionCall)
+		if !ok || err != nil {
+			return
+		}
+
+		builtIn, ok := builtIns.Functions[fc.name.ident]
+		if !ok {
+			return
+		}
+
+		for _, param := range builtIn.RegexParams {
+			// The regex parameters can be omitted when they have default values.
+			if param >= len(fc.arguments) {
+				continue
+			}
+
+			if regexErr := checkRegex(fc.arguments[param]); regexErr != nil {
+				err = exprErrorf(fc.arguments[param], "%v", regexErr)
+				return
+			}
+		}
+	})
+
+	return err
+}
+
+func isKindSupported(supportedKinds []handler.TargetEntityKind, kind handler.TargetEntityKind) bool {
+	for _, supportedKind := range supportedKinds {
+		if supportedKind == kind {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,184 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockLintBuiltIns() *aladino.BuiltIns {
+	builtIns := aladino.MockBuiltIns()
+	builtIns.Actions["review"] = &aladino.BuiltInAction{
+		Type: aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType()}, nil),
+		Code: func(e aladino.Env, args []aladino.Value) error {
+			return nil
+		},
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+	}
+	builtIns.Functions["matches"] = &aladino.BuiltInFunction{
+		Type: aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType(), aladino.BuildStringType()}, aladino.BuildBoolType()),
+		Code: func(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+			return aladino.BuildTrueValue(), nil
+		},
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+		RegexParams:    []int{1},
+	}
+
+	return builtIns
+}
+
+func TestLint(t *testing.T) {
+	file := &engine.ReviewpadFile{
+		Functions: []engine.PadFunction{
+			{
+				Name:       "isZero",
+				Parameters: []engine.PadFunctionParameter{{Name: "n", Type: "Int"}},
+				Spec:       "$n == $zeroConst()",
+			},
+		},
+		Groups: []engine.PadGroup{
+			{Name: "owners", Spec: `["john", $returnStr("jane")]`},
+		},
+		Rules: []engine.PadRule{
+			{Name: "is-zero", Spec: "$isZero(0)"},
+			{Name: "is-feature", Spec: `$matches($returnStr("feat: lint"), "^feat")`},
+		},
+		Workflows: []engine.PadWorkflow{
+			{
+				Name:    "review",
+				On:      []handler.TargetEntityKind{handler.PullRequest},
+				Rules:   []engine.PadWorkflowRule{{Rule: "is-zero", ExtraActions: []string{"$emptyAction()"}}},
+				Actions: []string{`$review("john")`},
+			},
+		},
+		Pipelines: []engine.PadPipeline{
+			{
+				Name:    "pipeline",
+				Trigger: "$zeroConst() == 0",
+				Stages:  []engine.PadStage{{Actions: []string{`$review("jane")`}, Until: "true"}},
+			},
+		},
+	}
+
+	err := aladino.Lint(file, mockLintBuiltIns())
+
+	assert.Nil(t, err)
+}
+
+func TestLint_WhenFileIsInvalid(t *testing.T) {
+	tests := map[string]struct {
+		file    *engine.ReviewpadFile
+		wantErr string
+	}{
+		"function with type error": {
+			file: &engine.ReviewpadFile{
+				Functions: []engine.PadFunction{{Name: "isBig", Spec: `$zeroConst() > "big"`}},
+			},
+			wantErr: "[lint] functions[0].spec: type inference failed",
+		},
+		"function that is recursive": {
+			file: &engine.ReviewpadFile{
+				Functions: []engine.PadFunction{
+					{Name: "isEven", Parameters: []engine.PadFunctionParameter{{Name: "n", Type: "Int"}}, Spec: `$n == 0 || "${isOdd($n - 1)}" == "true"`},
+					{Name: "isOdd", Parameters: []engine.PadFunctionParameter{{Name: "n", Type: "Int"}}, Spec: `$n != 0 && $isEven($n - 1)`},
+				},
+			},
+			wantErr: "[lint] function isEven is recursive: isEven -> isOdd -> isEven",
+		},
+		"function named as a built-in": {
+			file: &engine.ReviewpadFile{
+				Functions: []engine.PadFunction{{Name: "zeroConst", Spec: "1"}},
+			},
+			wantErr: "[lint] functions[0].spec: zeroConst is already a built-in",
+		},
+		"group that is not an array": {
+			file: &engine.ReviewpadFile{
+				Groups: []engine.PadGroup{{Name: "owners", Spec: `"john"`}},
+			},
+			wantErr: "[lint] groups[0].spec: expression is not a valid group",
+		},
+		"rule with type error": {
+			file: &engine.ReviewpadFile{
+				Rules: []engine.PadRule{{Name: "is-big", Spec: `$zeroConst() == "big"`}},
+			},
+			wantErr: "[lint] rules[0].spec: type inference failed",
+		},
+		"rule that is not a condition": {
+			file: &engine.ReviewpadFile{
+				Rules: []engine.PadRule{{Name: "is-big", Spec: "$zeroConst()"}},
+			},
+			wantErr: "[lint] rules[0].spec: expression $zeroConst() is not a condition",
+		},
+		"rule with invalid regex": {
+			file: &engine.ReviewpadFile{
+				Rules: []engine.PadRule{{Name: "is-feature", Spec: `$matches($returnStr("feat"), "[")`}},
+			},
+			wantErr: "[lint] rules[0].spec: invalid regex \"[\": error parsing regexp: missing closing ]: `[`",
+		},
+		"function with invalid regex in a let": {
+			file: &engine.ReviewpadFile{
+				Functions: []engine.PadFunction{{Name: "isFeature", Spec: `let $title = $returnStr("feat"); $matches($title, "(")`}},
+			},
+			wantErr: "[lint] functions[0].spec: invalid regex \"(\"",
+		},
+		"action with wrong number of args": {
+			file: &engine.ReviewpadFile{
+				Workflows: []engine.PadWorkflow{
+					{Name: "review", On: []handler.TargetEntityKind{handler.PullRequest}, Actions: []string{`$review("john", "jane")`}},
+				},
+			},
+			wantErr: "[lint] workflows[0].then[0]: type inference failed: mismatch in arg types on review",
+		},
+		"action that is a function": {
+			file: &engine.ReviewpadFile{
+				Workflows: []engine.PadWorkflow{
+					{Name: "review", On: []handler.TargetEntityKind{handler.PullRequest}, Actions: []string{`$returnStr("john")`}},
+				},
+			},
+			wantErr: "[lint] workflows[0].then[0]: returnStr is not a built-in action",
+		},
+		"else action that is a function": {
+			file: &engine.ReviewpadFile{
+				Workflows: []engine.PadWorkflow{
+					{Name: "review", On: []handler.TargetEntityKind{handler.PullRequest}, ElseActions: []string{`$returnStr("john")`}},
+				},
+			},
+			wantErr: "[lint] workflows[0].else[0]: returnStr is not a built-in action",
+		},
+		"extra action with unsupported kind": {
+			file: &engine.ReviewpadFile{
+				Workflows: []engine.PadWorkflow{
+					{
+						Name:  "review",
+						On:    []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+						Rules: []engine.PadWorkflowRule{{Rule: "tautology", ExtraActions: []string{`$review("john")`}}},
+					},
+				},
+			},
+			wantErr: "[lint] workflows[0].if[0].extra-actions[0]: action review does not support issues",
+		},
+		"pipeline stage with type error": {
+			file: &engine.ReviewpadFile{
+				Pipelines: []engine.PadPipeline{
+					{Name: "pipeline", Stages: []engine.PadStage{{Actions: []string{"$review(1)"}}}},
+				},
+			},
+			wantErr: "[lint] pipelines[0].stages[0].actions[0]: type inference failed: mismatch in arg types on review",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := aladino.Lint(test.file, mockLintBuiltIns())
+
+			assert.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
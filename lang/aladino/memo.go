@@ -0,0 +1,136 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Memo caches the values of the calls to pure built-ins during a run,
+// e.g. $team("core") asks GitHub for the members of the team once, however many rules call it.
+// The calls are identified by the name of the built-in and the values of their arguments.
+type Memo struct {
+	values map[string]Value
+	hits   int
+	misses int
+}
+
+func NewMemo() *Memo {
+	return &Memo{values: make(map[string]Value)}
+}
+
+// Stats returns the number of calls whose value was found in the cache and the number of calls that were evaluated.
+func (m *Memo) Stats() (int, int) {
+	if m == nil {
+		return 0, 0
+	}
+
+	return m.hits, m.misses
+}
+
+// call returns the cached value of the call to the built-in, evaluating it when it is not cached.
+// Only successful calls are cached so that failures are retried.
+func (m *Memo) call(name string, fn *BuiltInFunction, e Env, args []Value) (Value, error) {
+	if m == nil || !fn.Pure {
+		return fn.Code(e, args)
+	}
+
+	key, ok := memoKey(name, args)
+	if !ok {
+		return fn.Code(e, args)
+	}
+
+	if value, ok := m.values[key]; ok {
+		m.hits++
+		return copyValue(value), nil
+	}
+
+	m.misses++
+
+	value, err := fn.Code(e, args)
+	if err != nil {
+		return nil, err
+	}
+
+	m.values[key] = copyValue(value)
+
+	return value, nil
+}
+
+// copyValue copies the arrays of a value since the built-ins may change the arrays they are given.
+func copyValue(value Value) Value {
+	arrayValue, ok := value.(*ArrayValue)
+	if !ok {
+		return value
+	}
+
+	elems := make([]Value, len(arrayValue.Vals))
+	for i, elem := range arrayValue.Vals {
+		elems[i] = copyValue(elem)
+	}
+
+	return BuildArrayValue(elems)
+}
+
+// memoKey identifies the call to a built-in by its name and the values of its arguments, e.g. team("core").
+// Calls with functions as arguments, e.g. lambdas, are not identified since functions cannot be compared.
+func memoKey(name string, args []Value) (string, bool) {
+	argsKeys := make([]string, len(args))
+	for i, arg := range args {
+		argKey, ok := valueKey(arg)
+		if !ok {
+			return "", false
+		}
+
+		argsKeys[i] = argKey
+	}
+
+	return fmt.Sprintf("%v(%v)", name, strings.Join(argsKeys, ", ")), true
+}
+
+func valueKey(value Value) (string, bool) {
+	switch val := value.(type) {
+	case *StringValue:
+		return strconv.Quote(val.Val), true
+	case *IntValue:
+		return strconv.Itoa(val.Val), true
+	case *BoolValue:
+		return strconv.FormatBool(val.Val), true
+	case *TimeValue:
+		return fmt.Sprintf("time(%v)", val.Val), true
+	case *DurationValue:
+		return fmt.Sprintf("duration(%v)", val.Val), true
+	case *ArrayValue:
+		elemsKeys := make([]string, len(val.Vals))
+		for i, elem := range val.Vals {
+			elemKey, ok := valueKey(elem)
+			if !ok {
+				return "", false
+			}
+
+			elemsKeys[i] = elemKey
+		}
+
+		return fmt.Sprintf("[%v]", strings.Join(elemsKeys, ", ")), true
+	case *RecordValue:
+		fields := make([]string, 0, len(val.Vals))
+		for field, fieldValue := range val.Vals {
+			fieldKey, ok := valueKey(fieldValue)
+			if !ok {
+				return "", false
+			}
+
+			fields = append(fields, fmt.Sprintf("%v: %v", field, fieldKey))
+		}
+		sort.Strings(fields)
+
+		return fmt.Sprintf("{%v}", strings.Join(fields, ", ")), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,165 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockCountingBuiltIns mocks a built-in that returns its argument and counts its calls.
+func mockCountingBuiltIns(pure bool, calls *int) *BuiltIns {
+	return &BuiltIns{
+		Functions: map[string]*BuiltInFunction{
+			"members": {
+				Type: BuildFunctionType([]Type{BuildStringType()}, BuildArrayOfType(BuildStringType())),
+				Code: func(e Env, args []Value) (Value, error) {
+					*calls++
+					return BuildArrayValue([]Value{args[0]}), nil
+				},
+				SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+				Pure:           pure,
+			},
+		},
+	}
+}
+
+func TestMemo_WhenBuiltInIsPure(t *testing.T) {
+	calls := 0
+	mockedEnv := MockDefaultEnv(t, nil, nil, mockCountingBuiltIns(true, &calls), nil)
+
+	expr, err := Parse(`$members("core") == $members("core") && $members("core") != $members("docs")`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotVal, err := Eval(mockedEnv, expr)
+
+	hits, misses := mockedEnv.GetMemo().Stats()
+
+	assert.Nil(t, err)
+	assert.Equal(t, BuildTrueValue(), gotVal)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, hits)
+	assert.Equal(t, 2, misses)
+}
+
+func TestMemo_WhenBuiltInIsNotPure(t *testing.T) {
+	calls := 0
+	mockedEnv := MockDefaultEnv(t, nil, nil, mockCountingBuiltIns(false, &calls), nil)
+
+	expr, err := Parse(`$members("core") == $members("core")`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	_, err = Eval(mockedEnv, expr)
+
+	hits, misses := mockedEnv.GetMemo().Stats()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 0, hits)
+	assert.Equal(t, 0, misses)
+}
+
+func TestMemo_WhenCallFails(t *testing.T) {
+	calls := 0
+	fn := &BuiltInFunction{
+		Code: func(e Env, args []Value) (Value, error) {
+			calls++
+			return nil, errors.New("request failed")
+		},
+		Pure: true,
+	}
+	memo := NewMemo()
+
+	for i := 0; i < 2; i++ {
+		_, err := memo.call("fails", fn, nil, []Value{})
+
+		assert.EqualError(t, err, "request failed")
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestMemo_WhenCachedArrayIsChanged(t *testing.T) {
+	fn := &BuiltInFunction{
+		Code: func(e Env, args []Value) (Value, error) {
+			return BuildArrayValue([]Value{BuildStringValue("john"), BuildStringValue("jane")}), nil
+		},
+		Pure: true,
+	}
+	memo := NewMemo()
+
+	gotVal, _ := memo.call("members", fn, nil, []Value{})
+	gotVal.(*ArrayValue).Vals[0] = BuildStringValue("steve")
+
+	gotVal, _ = memo.call("members", fn, nil, []Value{})
+
+	assert.Equal(t, BuildArrayValue([]Value{BuildStringValue("john"), BuildStringValue("jane")}), gotVal)
+}
+
+func TestMemo_WhenMemoIsNil(t *testing.T) {
+	var memo *Memo
+	fn := &BuiltInFunction{
+		Code: func(e Env, args []Value) (Value, error) {
+			return BuildIntValue(1), nil
+		},
+		Pure: true,
+	}
+
+	gotVal, err := memo.call("one", fn, nil, []Value{})
+	hits, misses := memo.Stats()
+
+	assert.Nil(t, err)
+	assert.Equal(t, BuildIntValue(1), gotVal)
+	assert.Equal(t, 0, hits)
+	assert.Equal(t, 0, misses)
+}
+
+func TestMemoKey(t *testing.T) {
+	tests := map[string]struct {
+		args    []Value
+		wantKey string
+		wantOk  bool
+	}{
+		"no args": {
+			args:    []Value{},
+			wantKey: "fn()",
+			wantOk:  true,
+		},
+		"scalars": {
+			args:    []Value{BuildStringValue("1"), BuildIntValue(1), BuildTrueValue(), BuildTimeValue(1), BuildDurationValue(1)},
+			wantKey: `fn("1", 1, true, time(1), duration(1))`,
+			wantOk:  true,
+		},
+		"array": {
+			args:    []Value{BuildArrayValue([]Value{BuildStringValue("a"), BuildStringValue("b")})},
+			wantKey: `fn(["a", "b"])`,
+			wantOk:  true,
+		},
+		"record": {
+			args:    []Value{BuildRecordValue(map[string]Value{"b": BuildIntValue(2), "a": BuildIntValue(1)})},
+			wantKey: "fn({a: 1, b: 2})",
+			wantOk:  true,
+		},
+		"function": {
+			args: []Value{BuildFunctionValue(func(args []Value) (Value, error) { return nil, nil })},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotKey, gotOk := memoKey("fn", test.args)
+
+			assert.Equal(t, test.wantOk, gotOk)
+			assert.Equal(t, test.wantKey, gotKey)
+		})
+	}
+}
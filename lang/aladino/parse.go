@@ -10,13 +10,27 @@ import (
 )
 
 func Parse(input string) (Expr, error) {
+	expr, _, err := parse(input)
+	return expr, err
+}
+
+// parse builds the AST of the input together with the span of each of its nodes.
+func parse(input string) (Expr, *sourceMap, error) {
 	input = strings.TrimRight(input, "\n")
-	lex := &AladinoLex{input: input}
+	lex := &AladinoLex{
+		input:  input,
+		source: input,
+		spans:  make(map[Expr]Span),
+	}
 	res := AladinoParse(lex)
 
 	if res != 0 {
-		return nil, fmt.Errorf("parse error: failed to build AST on input %v", input)
+		if lex.err != nil {
+			return nil, nil, lex.err
+		}
+
+		return nil, nil, fmt.Errorf("parse error: failed to build AST on input %v", input)
 	}
 
-	return lex.ast, nil
+	return lex.ast, &sourceMap{source: input, spans: lex.spans}, nil
 }
@@ -109,3 +109,495 @@ func TestParse_TypedExpression(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, wantExpr, gotExpr)
 }
+
+func TestParse_TypedExpression_WhenArrayType(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		wantExpr Expr
+	}{
+		"array": {
+			input:    `$developers: []String`,
+			wantExpr: BuildTypedExpr(BuildVariable("developers"), BuildArrayOfType(BuildStringType())),
+		},
+		"nested array": {
+			input:    `$sizes:[][]Int`,
+			wantExpr: BuildTypedExpr(BuildVariable("sizes"), BuildArrayOfType(BuildArrayOfType(BuildIntType()))),
+		},
+		"lambda parameter": {
+			input: `($developers: []String => $developers)`,
+			wantExpr: BuildLambda(
+				[]Expr{BuildTypedExpr(BuildVariable("developers"), BuildArrayOfType(BuildStringType()))},
+				BuildVariable("developers"),
+			),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantExpr, gotExpr)
+		})
+	}
+}
+
+func TestParse_ArithmeticPrecedence(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		wantExpr Expr
+	}{
+		"multiplication binds tighter than addition": {
+			input: `1 + 2 * 3`,
+			wantExpr: BuildAddOp(
+				BuildIntConst(1),
+				BuildMulOp(BuildIntConst(2), BuildIntConst(3)),
+			),
+		},
+		"subtraction is left associative": {
+			input: `5 - 2 - 1`,
+			wantExpr: BuildSubOp(
+				BuildSubOp(BuildIntConst(5), BuildIntConst(2)),
+				BuildIntConst(1),
+			),
+		},
+		"arithmetic binds tighter than comparison": {
+			input: `$size() / 2 > 10 % 3`,
+			wantExpr: BuildGreaterThanOp(
+				BuildDivOp(BuildFunctionCall(BuildVariable("size"), []Expr{}), BuildIntConst(2)),
+				BuildModOp(BuildIntConst(10), BuildIntConst(3)),
+			),
+		},
+		"parentheses override precedence": {
+			input: `(1 + 2) * 3`,
+			wantExpr: BuildMulOp(
+				BuildAddOp(BuildIntConst(1), BuildIntConst(2)),
+				BuildIntConst(3),
+			),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantExpr, gotExpr)
+		})
+	}
+}
+
+func TestParse_Let(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		wantExpr Expr
+	}{
+		"single binding": {
+			input: `let $a = $author(); $a == "john"`,
+			wantExpr: BuildLet(
+				BuildVariable("a"),
+				BuildFunctionCall(BuildVariable("author"), []Expr{}),
+				BuildEqOp(BuildVariable("a"), BuildStringConst("john")),
+			),
+		},
+		"nested bindings": {
+			input: `let $a = 1; let $b = $a + 1; $b`,
+			wantExpr: BuildLet(
+				BuildVariable("a"),
+				BuildIntConst(1),
+				BuildLet(
+					BuildVariable("b"),
+					BuildAddOp(BuildVariable("a"), BuildIntConst(1)),
+					BuildVariable("b"),
+				),
+			),
+		},
+		"body extends as far right as possible": {
+			input: `let $a = 1; $a > 0 && $a < 2`,
+			wantExpr: BuildLet(
+				BuildVariable("a"),
+				BuildIntConst(1),
+				BuildAndOp(
+					BuildGreaterThanOp(BuildVariable("a"), BuildIntConst(0)),
+					BuildLessThanOp(BuildVariable("a"), BuildIntConst(2)),
+				),
+			),
+		},
+		"variable starting with keyword": {
+			input:    `$letter`,
+			wantExpr: BuildVariable("letter"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantExpr, gotExpr)
+		})
+	}
+}
+
+func TestParse_Conditional(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		wantExpr Expr
+	}{
+		"simple conditional": {
+			input: `if $base() == "main" then "core" else "all"`,
+			wantExpr: BuildConditional(
+				BuildEqOp(BuildFunctionCall(BuildVariable("base"), []Expr{}), BuildStringConst("main")),
+				BuildStringConst("core"),
+				BuildStringConst("all"),
+			),
+		},
+		"else branch extends as far right as possible": {
+			input: `if true then 1 else 2 + 3`,
+			wantExpr: BuildConditional(
+				BuildBoolConst(true),
+				BuildIntConst(1),
+				BuildAddOp(BuildIntConst(2), BuildIntConst(3)),
+			),
+		},
+		"nested conditional": {
+			input: `if true then 1 else if false then 2 else 3`,
+			wantExpr: BuildConditional(
+				BuildBoolConst(true),
+				BuildIntConst(1),
+				BuildConditional(BuildBoolConst(false), BuildIntConst(2), BuildIntConst(3)),
+			),
+		},
+		"conditional as operand": {
+			input: `(if true then 1 else 2) + 3`,
+			wantExpr: BuildAddOp(
+				BuildConditional(BuildBoolConst(true), BuildIntConst(1), BuildIntConst(2)),
+				BuildIntConst(3),
+			),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantExpr, gotExpr)
+		})
+	}
+}
+
+func TestParse_MatchOp(t *testing.T) {
+	gotExpr, err := Parse(`$title() =~ "^(feat|fix)\(\w+\):" && $base() == "main"`)
+
+	wantExpr := BuildAndOp(
+		BuildMatchOp(
+			BuildFunctionCall(BuildVariable("title"), []Expr{}),
+			BuildStringConst(`^(feat|fix)\(\w+\):`),
+		),
+		BuildEqOp(BuildFunctionCall(BuildVariable("base"), []Expr{}), BuildStringConst("main")),
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantExpr, gotExpr)
+}
+
+func TestParse_InOp(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		wantExpr Expr
+	}{
+		"in": {
+			input: `$author() in ["john", "jane"]`,
+			wantExpr: BuildInOp(
+				BuildFunctionCall(BuildVariable("author"), []Expr{}),
+				BuildArray([]Expr{BuildStringConst("john"), BuildStringConst("jane")}),
+			),
+		},
+		"not in": {
+			input:    `$label not  in $labels()`,
+			wantExpr: BuildNotInOp(BuildVariable("label"), BuildFunctionCall(BuildVariable("labels"), []Expr{})),
+		},
+		"binds looser than arithmetic": {
+			input:    `1 + 1 in [2] && true`,
+			wantExpr: BuildAndOp(BuildInOp(BuildAddOp(BuildIntConst(1), BuildIntConst(1)), BuildArray([]Expr{BuildIntConst(2)})), BuildBoolConst(true)),
+		},
+		"identifiers starting with in": {
+			input:    `$index in $indexes`,
+			wantExpr: BuildInOp(BuildVariable("index"), BuildVariable("indexes")),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantExpr, gotExpr)
+		})
+	}
+}
+
+func TestParse_WhenNameIsKeyword(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		wantExpr Expr
+	}{
+		"variables": {
+			input:    `$in in [$if, $then, $else, $let, $true]`,
+			wantExpr: BuildInOp(BuildVariable("in"), BuildArray([]Expr{BuildVariable("if"), BuildVariable("then"), BuildVariable("else"), BuildVariable("let"), BuildVariable("true")})),
+		},
+		"let binding": {
+			input:    `let $in = 1; $in`,
+			wantExpr: BuildLet(BuildVariable("in"), BuildIntConst(1), BuildVariable("in")),
+		},
+		"lambda parameter": {
+			input:    `($else: Int => if $else > 0 then $else else 0)`,
+			wantExpr: BuildLambda([]Expr{BuildTypedExpr(BuildVariable("else"), BuildIntType())}, BuildConditional(BuildGreaterThanOp(BuildVariable("else"), BuildIntConst(0)), BuildVariable("else"), BuildIntConst(0))),
+		},
+		"function call": {
+			input:    `$if($then)`,
+			wantExpr: BuildFunctionCall(BuildVariable("if"), []Expr{BuildVariable("then")}),
+		},
+		"field": {
+			input:    `$review.in`,
+			wantExpr: BuildFieldAccess(BuildVariable("review"), "in"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantExpr, gotExpr)
+		})
+	}
+}
+
+func TestParse_FieldAccess(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		wantExpr Expr
+	}{
+		"on variable": {
+			input:    `$review.user`,
+			wantExpr: BuildFieldAccess(BuildVariable("review"), "user"),
+		},
+		"nested": {
+			input:    `$commit.author.login`,
+			wantExpr: BuildFieldAccess(BuildFieldAccess(BuildVariable("commit"), "author"), "login"),
+		},
+		"binds tighter than operators": {
+			input: `!$review.dismissed && $review.state == "APPROVED"`,
+			wantExpr: BuildAndOp(
+				BuildNotOp(BuildFieldAccess(BuildVariable("review"), "dismissed")),
+				BuildEqOp(BuildFieldAccess(BuildVariable("review"), "state"), BuildStringConst("APPROVED")),
+			),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantExpr, gotExpr)
+		})
+	}
+}
+
+func TestParse_Interpolation(t *testing.T) {
+	tests := map[string]struct {
+		input    string
+		wantExpr Expr
+	}{
+		"without placeholders": {
+			input:    `"size is $size"`,
+			wantExpr: BuildStringConst("size is $size"),
+		},
+		"with placeholders": {
+			input: `"Hi @${$author()}, size is ${$size() + 1}"`,
+			wantExpr: BuildInterpolation([]Expr{
+				BuildStringConst("Hi @"),
+				BuildFunctionCall(BuildVariable("author"), []Expr{}),
+				BuildStringConst(", size is "),
+				BuildAddOp(BuildFunctionCall(BuildVariable("size"), []Expr{}), BuildIntConst(1)),
+			}),
+		},
+		"with function call shorthand": {
+			input: `"Hi @${author()}"`,
+			wantExpr: BuildInterpolation([]Expr{
+				BuildStringConst("Hi @"),
+				BuildFunctionCall(BuildVariable("author"), []Expr{}),
+			}),
+		},
+		"with nested string literals": {
+			input: `"${if $isDraft() then "draft ${title()}" else "ready"}"`,
+			wantExpr: BuildInterpolation([]Expr{
+				BuildConditional(
+					BuildFunctionCall(BuildVariable("isDraft"), []Expr{}),
+					BuildInterpolation([]Expr{
+						BuildStringConst("draft "),
+						BuildFunctionCall(BuildVariable("title"), []Expr{}),
+					}),
+					BuildStringConst("ready"),
+				),
+			}),
+		},
+		"with escaped placeholder": {
+			input: `"\${author()} is ${author()}"`,
+			wantExpr: BuildInterpolation([]Expr{
+				BuildStringConst("${author()} is "),
+				BuildFunctionCall(BuildVariable("author"), []Expr{}),
+			}),
+		},
+		"with string literal placeholders": {
+			input: `"${"}"}${"a\"}${size()}"`,
+			wantExpr: BuildInterpolation([]Expr{
+				BuildStringConst(`}a\`),
+				BuildFunctionCall(BuildVariable("size"), []Expr{}),
+			}),
+		},
+		"with only string literal placeholders": {
+			input:    `"${"}"}"`,
+			wantExpr: BuildStringConst("}"),
+		},
+		"with text that is not a placeholder": {
+			input:    `"see ${{ github.sha }} and use ${capture}"`,
+			wantExpr: BuildStringConst("see ${{ github.sha }} and use ${capture}"),
+		},
+		"with escaped text that is not a placeholder": {
+			input:    `"use \${capture}"`,
+			wantExpr: BuildStringConst("use ${capture}"),
+		},
+		"with text that is not a placeholder before a placeholder": {
+			input: `"${x" + "${size()}"`,
+			wantExpr: BuildAddOp(
+				BuildStringConst("${x"),
+				BuildInterpolation([]Expr{BuildFunctionCall(BuildVariable("size"), []Expr{})}),
+			),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(test.input)
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantExpr, gotExpr)
+		})
+	}
+}
+
+func TestParse_Interpolation_WhenPlaceholderIsInvalid(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		wantErr string
+	}{
+		"unterminated placeholder": {
+			input: `$comment("Hi ${author()")`,
+			wantErr: `parse error: unterminated placeholder
+ --> line 1, column 14
+  |
+1 | $comment("Hi ${author()")
+  |              ^^`,
+		},
+		"syntax error in placeholder": {
+			input: `$comment("Hi ${author(})")`,
+			wantErr: `parse error: unexpected end of input
+ --> line 1, column 23
+  |
+1 | $comment("Hi ${author(})")
+  |                       ^`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(test.input)
+			assert.Nil(t, gotExpr)
+			assert.EqualError(t, err, test.wantErr)
+		})
+	}
+}
+
+func TestParse_Interpolation_Spans(t *testing.T) {
+	input := `"Hi @${author()}, size is ${$size() + 1}"`
+
+	expr, sourceMap, err := parse(input)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	interpolation := expr.(*Interpolation)
+
+	wantSpans := map[Expr]string{
+		interpolation:          input,
+		interpolation.parts[1]: `author()`,
+		interpolation.parts[3]: `$size() + 1`,
+	}
+
+	for expr, wantSource := range wantSpans {
+		span := sourceMap.spans[expr]
+		assert.Equal(t, wantSource, input[span.Start:span.End])
+	}
+}
+
+func TestParse_WhenSyntaxError(t *testing.T) {
+	gotExpr, err := Parse(`$size() < 10 && )`)
+
+	wantErr := `parse error: unexpected ")"
+ --> line 1, column 17
+  |
+1 | $size() < 10 && )
+  |                 ^`
+
+	assert.Nil(t, gotExpr)
+	assert.EqualError(t, err, wantErr)
+}
+
+func TestParse_WhenDurationIsInMonthsOrYears(t *testing.T) {
+	tests := map[string]string{
+		"months": `$createdAt() + 2 months`,
+		"year":   `$createdAt() + 1 year`,
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(input)
+
+			assert.Nil(t, gotExpr)
+			assert.ErrorContains(t, err, "parse error")
+		})
+	}
+}
+
+func TestParse_WhenRelativeTimestampIsInMonths(t *testing.T) {
+	gotExpr, err := Parse(`$createdAt() < 2 months ago`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, `$createdAt() < 2 months ago`, Print(gotExpr))
+}
+
+func TestParse_Spans(t *testing.T) {
+	input := `($size() + 1) * 2 > $review.submittedAt`
+
+	expr, sourceMap, err := parse(input)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gtOp := expr.(*BinaryOp)
+	mulOp := gtOp.lhs.(*BinaryOp)
+	addOp := mulOp.lhs.(*BinaryOp)
+	sizeCall := addOp.lhs.(*FunctionCall)
+	fieldAccess := gtOp.rhs.(*FieldAccess)
+
+	wantSpans := map[Expr]string{
+		gtOp:             input,
+		mulOp:            `($size() + 1) * 2`,
+		addOp:            `$size() + 1`,
+		sizeCall:         `$size()`,
+		sizeCall.name:    `$size`,
+		fieldAccess:      `$review.submittedAt`,
+		fieldAccess.expr: `$review`,
+	}
+
+	for expr, wantSource := range wantSpans {
+		span := sourceMap.spans[expr]
+		assert.Equal(t, wantSource, input[span.Start:span.End])
+	}
+}
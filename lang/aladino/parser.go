@@ -13,6 +13,13 @@ func setAST(l AladinoLexer, root Expr) {
 	l.(*AladinoLex).ast = root
 }
 
+// spanned records that expr goes from the start of the first symbol to the end of the last one.
+func spanned(l AladinoLexer, expr Expr, first Span, last Span) Span {
+	span := Span{first.Start, last.End}
+	l.(*AladinoLex).spans[expr] = span
+	return span
+}
+
 type AladinoSymType struct {
 	yys     int
 	str     string
@@ -21,23 +28,34 @@ type AladinoSymType struct {
 	astList []Expr
 	bool    bool
 	varType Type
+	span    Span
 }
 
 const TIMESTAMP = 57346
 const RELATIVETIMESTAMP = 57347
-const IDENTIFIER = 57348
-const STRINGLITERAL = 57349
-const TK_CMPOP = 57350
-const TK_LAMBDA = 57351
-const TK_TYPE = 57352
-const NUMBER = 57353
-const TRUE = 57354
-const FALSE = 57355
-const TK_OR = 57356
-const TK_AND = 57357
-const TK_EQ = 57358
-const TK_NEQ = 57359
-const TK_NOT = 57360
+const DURATION = 57348
+const IDENTIFIER = 57349
+const STRINGLITERAL = 57350
+const TK_CMPOP = 57351
+const TK_LAMBDA = 57352
+const TK_TYPE = 57353
+const TK_LET = 57354
+const TK_IF = 57355
+const TK_THEN = 57356
+const TK_ELSE = 57357
+const INTERPOLATION = 57358
+const ILLEGAL = 57359
+const NUMBER = 57360
+const TRUE = 57361
+const FALSE = 57362
+const TK_OR = 57363
+const TK_AND = 57364
+const TK_EQ = 57365
+const TK_NEQ = 57366
+const TK_MATCH = 57367
+const TK_IN = 57368
+const TK_NOT_IN = 57369
+const TK_NOT = 57370
 
 var AladinoToknames = [...]string{
 	"$end",
@@ -45,11 +63,18 @@ var AladinoToknames = [...]string{
 	"$unk",
 	"TIMESTAMP",
 	"RELATIVETIMESTAMP",
+	"DURATION",
 	"IDENTIFIER",
 	"STRINGLITERAL",
 	"TK_CMPOP",
 	"TK_LAMBDA",
 	"TK_TYPE",
+	"TK_LET",
+	"TK_IF",
+	"TK_THEN",
+	"TK_ELSE",
+	"INTERPOLATION",
+	"ILLEGAL",
 	"NUMBER",
 	"TRUE",
 	"FALSE",
@@ -57,12 +82,23 @@ var AladinoToknames = [...]string{
 	"TK_AND",
 	"TK_EQ",
 	"TK_NEQ",
+	"TK_MATCH",
+	"TK_IN",
+	"TK_NOT_IN",
+	"'+'",
+	"'-'",
+	"'*'",
+	"'/'",
+	"'%'",
 	"TK_NOT",
+	"'.'",
 	"'('",
 	"')'",
 	"'['",
 	"']'",
 	"'$'",
+	"'='",
+	"';'",
 	"','",
 }
 
@@ -82,73 +118,108 @@ var AladinoExca = [...]int{
 
 const AladinoPrivate = 57344
 
-const AladinoLast = 85
+const AladinoLast = 286
 
 var AladinoAct = [...]int{
-	23, 2, 21, 33, 19, 20, 39, 34, 18, 32,
-	24, 17, 22, 18, 25, 26, 27, 28, 29, 15,
-	16, 5, 6, 1, 8, 0, 0, 0, 7, 11,
-	12, 0, 0, 36, 35, 3, 4, 37, 9, 17,
-	10, 18, 0, 0, 0, 14, 13, 15, 16, 0,
-	17, 30, 18, 0, 0, 31, 14, 13, 15, 16,
-	0, 17, 0, 18, 0, 0, 31, 14, 13, 15,
-	16, 0, 17, 38, 18, 17, 0, 18, 14, 13,
-	15, 16, 13, 15, 16,
+	36, 2, 64, 34, 32, 33, 38, 57, 67, 30,
+	58, 56, 1, 59, 53, 35, 30, 39, 40, 41,
+	42, 43, 44, 45, 46, 47, 48, 49, 50, 51,
+	52, 21, 31, 30, 37, 27, 28, 29, 0, 31,
+	0, 0, 0, 18, 17, 19, 20, 22, 23, 24,
+	25, 26, 27, 28, 29, 0, 31, 62, 54, 61,
+	0, 65, 63, 0, 55, 68, 21, 0, 30, 0,
+	71, 72, 0, 0, 0, 0, 0, 30, 18, 17,
+	19, 20, 22, 23, 24, 25, 26, 27, 28, 29,
+	21, 31, 30, 0, 25, 26, 27, 28, 29, 55,
+	31, 0, 18, 17, 19, 20, 22, 23, 24, 25,
+	26, 27, 28, 29, 0, 31, 5, 6, 7, 0,
+	9, 0, 70, 0, 15, 16, 0, 0, 10, 0,
+	8, 13, 14, 0, 0, 0, 0, 0, 0, 0,
+	21, 0, 30, 0, 0, 3, 0, 4, 0, 11,
+	0, 12, 18, 17, 19, 20, 22, 23, 24, 25,
+	26, 27, 28, 29, 21, 31, 30, 66, 0, 0,
+	69, 0, 0, 0, 0, 0, 18, 17, 19, 20,
+	22, 23, 24, 25, 26, 27, 28, 29, 21, 31,
+	30, 0, 0, 60, 0, 0, 0, 0, 0, 0,
+	18, 17, 19, 20, 22, 23, 24, 25, 26, 27,
+	28, 29, 21, 31, 30, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 18, 17, 19, 20, 22, 23,
+	24, 25, 26, 27, 28, 29, 21, 31, 30, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 17,
+	19, 20, 22, 23, 24, 25, 26, 27, 28, 29,
+	21, 31, 30, 0, 0, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 19, 20, 22, 23, 24, 25,
+	26, 27, 28, 29, 0, 31,
 }
 
 var AladinoPact = [...]int{
-	17, -1000, 64, 17, 17, -1000, -1000, -1000, -1000, 17,
-	4, -1000, -1000, 17, 17, 17, 17, 17, -1000, -2,
-	31, 0, -19, 42, -12, 3, 67, -2, -2, -2,
-	-1000, 17, 17, -1000, 17, -1000, 53, -14, -1000, -1000,
+	112, -1000, 203, 112, 112, -1000, -1000, -1000, -1000, -1000,
+	-1000, 112, 27, -1000, -1000, -33, 112, 112, 112, 112,
+	112, 112, 112, 112, 112, 112, 112, 112, 112, 112,
+	-1000, 7, -2, 22, 1, -31, 57, -25, 6, 179,
+	251, 227, 66, 66, 66, 66, 66, 66, 5, 5,
+	-2, -2, -2, -1000, -1000, 112, 112, -1000, 112, -38,
+	112, -1000, 131, -28, 112, 155, -1000, -1000, 81, 112,
+	112, 203, 203,
 }
 
 var AladinoPgo = [...]int{
-	0, 0, 2, 23,
+	0, 0, 3, 12,
 }
 
 var AladinoR1 = [...]int{
 	0, 3, 1, 1, 1, 1, 1, 1, 1, 1,
 	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	2, 2, 2,
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 2, 2, 2,
 }
 
 var AladinoR2 = [...]int{
-	0, 1, 2, 3, 3, 3, 3, 3, 3, 1,
+	0, 1, 2, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 1, 1, 1,
 	1, 1, 1, 3, 2, 1, 1, 5, 5, 2,
-	3, 1, 0,
+	3, 7, 6, 3, 1, 0,
 }
 
 var AladinoChk = [...]int{
-	-1000, -3, -1, 18, 19, 4, 5, 11, 7, 21,
-	23, 12, 13, 15, 14, 16, 17, 8, 10, -1,
-	-1, -2, -2, -1, 6, -1, -1, -1, -1, -1,
-	20, 24, 9, 22, 19, -2, -1, -2, 20, 20,
+	-1000, -3, -1, 33, 35, 4, 5, 6, 18, 8,
+	16, 37, 39, 19, 20, 12, 13, 22, 21, 23,
+	24, 9, 25, 26, 27, 28, 29, 30, 31, 32,
+	11, 34, -1, -1, -2, -2, -1, 7, 39, -1,
+	-1, -1, -1, -1, -1, -1, -1, -1, -1, -1,
+	-1, -1, -1, 7, 36, 42, 10, 38, 35, 7,
+	14, -2, -1, -2, 40, -1, 36, 36, -1, 15,
+	41, -1, -1,
 }
 
 var AladinoDef = [...]int{
-	0, -2, 1, 0, 22, 9, 10, 11, 12, 22,
-	0, 15, 16, 0, 0, 0, 0, 0, 19, 2,
-	21, 0, 0, 21, 14, 3, 4, 5, 6, 7,
-	8, 22, 0, 13, 22, 20, 0, 0, 18, 17,
+	0, -2, 1, 0, 35, 17, 18, 19, 20, 21,
+	22, 35, 0, 25, 26, 0, 0, 0, 0, 0,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+	29, 0, 2, 34, 0, 0, 34, 24, 0, 0,
+	3, 4, 5, 6, 7, 8, 9, 10, 11, 12,
+	13, 14, 15, 30, 16, 35, 0, 23, 35, 0,
+	0, 33, 0, 0, 0, 0, 28, 27, 0, 0,
+	0, 32, 31,
 }
 
 var AladinoTok1 = [...]int{
 	1, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 23, 3, 3, 3,
-	19, 20, 3, 3, 24, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 39, 32, 3, 3,
+	35, 36, 30, 28, 42, 29, 34, 31, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 41,
+	3, 40, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 21, 3, 22,
+	3, 37, 3, 38,
 }
 
 var AladinoTok2 = [...]int{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
-	12, 13, 14, 15, 16, 17, 18,
+	12, 13, 14, 15, 16, 17, 18, 19, 20, 21,
+	22, 23, 24, 25, 26, 27, 33,
 }
 
 var AladinoTok3 = [...]int{
@@ -499,103 +570,203 @@ Aladinodefault:
 		AladinoDollar = AladinoS[Aladinopt-2 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildNotOp(AladinoDollar[2].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[2].span)
 		}
 	case 3:
 		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildAndOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
 		}
 	case 4:
 		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildOrOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
 		}
 	case 5:
 		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildEqOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
 		}
 	case 6:
 		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildNeqOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
 		}
 	case 7:
 		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildCmpOp(AladinoDollar[1].ast, AladinoDollar[2].str, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
 		}
 	case 8:
 		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
 		{
-			AladinoVAL.ast = AladinoDollar[2].ast
+			AladinoVAL.ast = BuildMatchOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
 		}
 	case 9:
+		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
+		{
+			AladinoVAL.ast = BuildInOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
+		}
+	case 10:
+		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
+		{
+			AladinoVAL.ast = BuildNotInOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
+		}
+	case 11:
+		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
+		{
+			AladinoVAL.ast = BuildAddOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
+		}
+	case 12:
+		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
+		{
+			AladinoVAL.ast = BuildSubOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
+		}
+	case 13:
+		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
+		{
+			AladinoVAL.ast = BuildMulOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
+		}
+	case 14:
+		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
+		{
+			AladinoVAL.ast = BuildDivOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
+		}
+	case 15:
+		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
+		{
+			AladinoVAL.ast = BuildModOp(AladinoDollar[1].ast, AladinoDollar[3].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
+		}
+	case 16:
+		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
+		{
+			AladinoVAL.ast = AladinoDollar[2].ast
+			AladinoVAL.span = Span{AladinoDollar[1].span.Start, AladinoDollar[3].span.End}
+		}
+	case 17:
 		AladinoDollar = AladinoS[Aladinopt-1 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildTimeConst(AladinoDollar[1].str)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[1].span)
 		}
-	case 10:
+	case 18:
 		AladinoDollar = AladinoS[Aladinopt-1 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildRelativeTimeConst(AladinoDollar[1].str)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[1].span)
 		}
-	case 11:
+	case 19:
+		AladinoDollar = AladinoS[Aladinopt-1 : Aladinopt+1]
+		{
+			AladinoVAL.ast = BuildDurationConst(AladinoDollar[1].str)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[1].span)
+		}
+	case 20:
 		AladinoDollar = AladinoS[Aladinopt-1 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildIntConst(AladinoDollar[1].int)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[1].span)
 		}
-	case 12:
+	case 21:
 		AladinoDollar = AladinoS[Aladinopt-1 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildStringConst(AladinoDollar[1].str)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[1].span)
 		}
-	case 13:
+	case 22:
+		AladinoDollar = AladinoS[Aladinopt-1 : Aladinopt+1]
+		{
+			AladinoVAL.ast = AladinoDollar[1].ast
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[1].span)
+		}
+	case 23:
 		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildArray(AladinoDollar[2].astList)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
 		}
-	case 14:
+	case 24:
 		AladinoDollar = AladinoS[Aladinopt-2 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildVariable(AladinoDollar[2].str)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[2].span)
 		}
-	case 15:
+	case 25:
 		AladinoDollar = AladinoS[Aladinopt-1 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildBoolConst(true)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[1].span)
 		}
-	case 16:
+	case 26:
 		AladinoDollar = AladinoS[Aladinopt-1 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildBoolConst(false)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[1].span)
 		}
-	case 17:
+	case 27:
 		AladinoDollar = AladinoS[Aladinopt-5 : Aladinopt+1]
 		{
-			AladinoVAL.ast = BuildFunctionCall(BuildVariable(AladinoDollar[2].str), AladinoDollar[4].astList)
+			name := BuildVariable(AladinoDollar[2].str)
+			spanned(Aladinolex, name, AladinoDollar[1].span, AladinoDollar[2].span)
+			AladinoVAL.ast = BuildFunctionCall(name, AladinoDollar[4].astList)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[5].span)
 		}
-	case 18:
+	case 28:
 		AladinoDollar = AladinoS[Aladinopt-5 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildLambda(AladinoDollar[2].astList, AladinoDollar[4].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[5].span)
 		}
-	case 19:
+	case 29:
 		AladinoDollar = AladinoS[Aladinopt-2 : Aladinopt+1]
 		{
 			AladinoVAL.ast = BuildTypedExpr(AladinoDollar[1].ast, ParseType(AladinoDollar[2].str))
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[2].span)
 		}
-	case 20:
+	case 30:
+		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
+		{
+			AladinoVAL.ast = BuildFieldAccess(AladinoDollar[1].ast, AladinoDollar[3].str)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[3].span)
+		}
+	case 31:
+		AladinoDollar = AladinoS[Aladinopt-7 : Aladinopt+1]
+		{
+			variable := BuildVariable(AladinoDollar[3].str)
+			spanned(Aladinolex, variable, AladinoDollar[2].span, AladinoDollar[3].span)
+			AladinoVAL.ast = BuildLet(variable, AladinoDollar[5].ast, AladinoDollar[7].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[7].span)
+		}
+	case 32:
+		AladinoDollar = AladinoS[Aladinopt-6 : Aladinopt+1]
+		{
+			AladinoVAL.ast = BuildConditional(AladinoDollar[2].ast, AladinoDollar[4].ast, AladinoDollar[6].ast)
+			AladinoVAL.span = spanned(Aladinolex, AladinoVAL.ast, AladinoDollar[1].span, AladinoDollar[6].span)
+		}
+	case 33:
 		AladinoDollar = AladinoS[Aladinopt-3 : Aladinopt+1]
 		{
 			AladinoVAL.astList = append([]Expr{AladinoDollar[1].ast}, AladinoDollar[3].astList...)
 		}
-	case 21:
+	case 34:
 		AladinoDollar = AladinoS[Aladinopt-1 : Aladinopt+1]
 		{
 			AladinoVAL.astList = []Expr{AladinoDollar[1].ast}
 		}
-	case 22:
+	case 35:
 		AladinoDollar = AladinoS[Aladinopt-0 : Aladinopt+1]
 		{
 			AladinoVAL.astList = []Expr{}
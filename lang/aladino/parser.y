@@ -10,6 +10,13 @@ var base int
 func setAST(l AladinoLexer, root Expr) {
     l.(*AladinoLex).ast = root
 }
+
+// spanned records that expr goes from the start of the first symbol to the end of the last one.
+func spanned(l AladinoLexer, expr Expr, first Span, last Span) Span {
+    span := Span{first.Start, last.End}
+    l.(*AladinoLex).spans[expr] = span
+    return span
+}
 %}
 
 // fields inside this union end up as the fields in a structure known
@@ -21,6 +28,7 @@ func setAST(l AladinoLexer, root Expr) {
     astList []Expr
     bool bool
     varType Type
+    span Span
 }
 
 // any non-terminal which returns a value needs a type, which is
@@ -29,15 +37,21 @@ func setAST(l AladinoLexer, root Expr) {
 %type <astList> expr_list
 
 // same for terminals
-%token <str> TIMESTAMP RELATIVETIMESTAMP IDENTIFIER STRINGLITERAL TK_CMPOP TK_LAMBDA TK_TYPE
+%token <str> TIMESTAMP RELATIVETIMESTAMP DURATION IDENTIFIER STRINGLITERAL TK_CMPOP TK_LAMBDA TK_TYPE TK_LET TK_IF TK_THEN TK_ELSE
+%token <ast> INTERPOLATION
+%token ILLEGAL
 %token <int> NUMBER
 %token <bool> TRUE
 %token <bool> FALSE
 
+%nonassoc TK_LET TK_ELSE
 %left TK_OR
 %left TK_AND
-%left TK_EQ TK_NEQ TK_CMPOP
+%left TK_EQ TK_NEQ TK_CMPOP TK_MATCH TK_IN TK_NOT_IN
+%left '+' '-'
+%left '*' '/' '%'
 %left TK_NOT
+%left '.'
 
 %%
 
@@ -46,25 +60,78 @@ prog :
 ;
 
 expr :
-      TK_NOT expr        { $$ = BuildNotOp($2) }
-    | expr TK_AND expr   { $$ = BuildAndOp($1, $3) }
-    | expr TK_OR expr    { $$ = BuildOrOp($1, $3) }
-    | expr TK_EQ expr    { $$ = BuildEqOp($1, $3) }
-    | expr TK_NEQ expr   { $$ = BuildNeqOp($1, $3) }
-    | expr TK_CMPOP expr { $$ = BuildCmpOp($1, $2, $3) }
-    | '(' expr ')'       { $$ = $2 }
-    | TIMESTAMP          { $$ = BuildTimeConst($1) }
-    | RELATIVETIMESTAMP  { $$ = BuildRelativeTimeConst($1) }
-    | NUMBER             { $$ = BuildIntConst($1) }
-    | STRINGLITERAL      { $$ = BuildStringConst($1) }
-    | '[' expr_list ']'  { $$ = BuildArray($2) }
-    | '$' IDENTIFIER     { $$ = BuildVariable($2) }
-    | TRUE               { $$ = BuildBoolConst(true) }
-    | FALSE              { $$ = BuildBoolConst(false) }
-    | '$' IDENTIFIER '(' expr_list ')' 
-        { $$ = BuildFunctionCall(BuildVariable($2), $4) }
-    | '(' expr_list TK_LAMBDA expr  ')'      { $$ = BuildLambda($2, $4) }
-    | expr TK_TYPE  { $$ = BuildTypedExpr($1, ParseType($2)) }
+      TK_NOT expr
+        { $$ = BuildNotOp($2); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>2) }
+    | expr TK_AND expr
+        { $$ = BuildAndOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr TK_OR expr
+        { $$ = BuildOrOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr TK_EQ expr
+        { $$ = BuildEqOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr TK_NEQ expr
+        { $$ = BuildNeqOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr TK_CMPOP expr
+        { $$ = BuildCmpOp($1, $2, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr TK_MATCH expr
+        { $$ = BuildMatchOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr TK_IN expr
+        { $$ = BuildInOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr TK_NOT_IN expr
+        { $$ = BuildNotInOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr '+' expr
+        { $$ = BuildAddOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr '-' expr
+        { $$ = BuildSubOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr '*' expr
+        { $$ = BuildMulOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr '/' expr
+        { $$ = BuildDivOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | expr '%' expr
+        { $$ = BuildModOp($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | '(' expr ')'
+        { $$ = $2; $<span>$ = Span{$<span>1.Start, $<span>3.End} }
+    | TIMESTAMP
+        { $$ = BuildTimeConst($1); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>1) }
+    | RELATIVETIMESTAMP
+        { $$ = BuildRelativeTimeConst($1); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>1) }
+    | DURATION
+        { $$ = BuildDurationConst($1); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>1) }
+    | NUMBER
+        { $$ = BuildIntConst($1); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>1) }
+    | STRINGLITERAL
+        { $$ = BuildStringConst($1); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>1) }
+    | INTERPOLATION
+        { $$ = $1; $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>1) }
+    | '[' expr_list ']'
+        { $$ = BuildArray($2); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | '$' IDENTIFIER
+        { $$ = BuildVariable($2); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>2) }
+    | TRUE
+        { $$ = BuildBoolConst(true); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>1) }
+    | FALSE
+        { $$ = BuildBoolConst(false); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>1) }
+    | '$' IDENTIFIER '(' expr_list ')'
+        {
+            name := BuildVariable($2)
+            spanned(Aladinolex, name, $<span>1, $<span>2)
+            $$ = BuildFunctionCall(name, $4)
+            $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>5)
+        }
+    | '(' expr_list TK_LAMBDA expr ')'
+        { $$ = BuildLambda($2, $4); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>5) }
+    | expr TK_TYPE
+        { $$ = BuildTypedExpr($1, ParseType($2)); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>2) }
+    | expr '.' IDENTIFIER
+        { $$ = BuildFieldAccess($1, $3); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>3) }
+    | TK_LET '$' IDENTIFIER '=' expr ';' expr %prec TK_LET
+        {
+            variable := BuildVariable($3)
+            spanned(Aladinolex, variable, $<span>2, $<span>3)
+            $$ = BuildLet(variable, $5, $7)
+            $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>7)
+        }
+    | TK_IF expr TK_THEN expr TK_ELSE expr
+        { $$ = BuildConditional($2, $4, $6); $<span>$ = spanned(Aladinolex, $$, $<span>1, $<span>6) }
 ;
 
 expr_list :
@@ -0,0 +1,294 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The precedence of the expressions follows the one of the grammar in parser.y.
+// Let and if expressions go as far to the right as possible so they have the lowest precedence.
+const (
+	openPrecedence = iota
+	orPrecedence
+	andPrecedence
+	comparisonPrecedence
+	additivePrecedence
+	multiplicativePrecedence
+	notPrecedence
+	fieldAccessPrecedence
+	atomPrecedence
+)
+
+// Print returns the source code of the expression in canonical form.
+// Parsing the printed source code results in the same expression.
+func Print(expr Expr) string {
+	return expr.print()
+}
+
+// printOperand prints expr as an operand of an expression with the given precedence.
+func printOperand(expr Expr, precedence int) string {
+	if exprPrecedence(expr) < precedence {
+		return fmt.Sprintf("(%v)", expr.print())
+	}
+
+	return expr.print()
+}
+
+func exprPrecedence(expr Expr) int {
+	switch e := expr.(type) {
+	case *Let, *Conditional:
+		return openPrecedence
+	case *UnaryOp:
+		return notPrecedence
+	case *FieldAccess:
+		return fieldAccessPrecedence
+	case *BinaryOp:
+		switch e.op.getOperator() {
+		case OR_OP:
+			return orPrecedence
+		case AND_OP:
+			return andPrecedence
+		case ADD_OP, SUB_OP:
+			return additivePrecedence
+		case MUL_OP, DIV_OP, MOD_OP:
+			return multiplicativePrecedence
+		}
+		return comparisonPrecedence
+	}
+
+	return atomPrecedence
+}
+
+func printExprs(exprs []Expr) string {
+	printedExprs := make([]string, len(exprs))
+	for i, expr := range exprs {
+		printedExprs[i] = expr.print()
+	}
+
+	return strings.Join(printedExprs, ", ")
+}
+
+// printStringText escapes the text of a string literal so that it is not taken as a placeholder.
+func printStringText(text string) string {
+	return strings.ReplaceAll(text, "${", `\${`)
+}
+
+// PrintType returns the type as it is written in the source code, e.g. []String.
+// The types that cannot be written, e.g. the types of built-ins, are printed in the same notation, e.g. (Int) => Bool.
+func PrintType(ty Type) string {
+	if ty == nil {
+		return "Unit"
+	}
+
+	return printType(ty)
+}
+
+func printType(ty Type) string {
+	switch ty.Kind() {
+	case STRING_TYPE:
+		return "String"
+	case INT_TYPE:
+		return "Int"
+	case BOOL_TYPE:
+		return "Bool"
+	case TIME_TYPE:
+		return "Time"
+	case DURATION_TYPE:
+		return "Duration"
+	case ARRAY_OF_TYPE:
+		return "[]" + printType(ty.(*ArrayOfType).elemType)
+	case ARRAY_TYPE:
+		return fmt.Sprintf("[%v]", printTypes(ty.(*ArrayType).elemsType))
+	case RECORD_TYPE:
+		fieldsType := ty.(*RecordType).fieldsType
+		fields := make([]string, 0, len(fieldsType))
+		for field, fieldType := range fieldsType {
+			fields = append(fields, fmt.Sprintf("%v: %v", field, printType(fieldType)))
+		}
+		sort.Strings(fields)
+
+		return fmt.Sprintf("{%v}", strings.Join(fields, ", "))
+	case FUNCTION_TYPE:
+		fnType := ty.(*FunctionType)
+		return fmt.Sprintf("(%v) => %v", printTypes(fnType.paramTypes), PrintType(fnType.returnType))
+	case TYPE_VARIABLE:
+		return ty.(*TypeVariable).name
+	}
+
+	return ty.Kind()
+}
+
+func printTypes(types []Type) string {
+	printedTypes := make([]string, len(types))
+	for i, ty := range types {
+		printedTypes[i] = PrintType(ty)
+	}
+
+	return strings.Join(printedTypes, ", ")
+}
+
+// PrintValue returns the value as it is written in the source code, e.g. ["john", "jane"].
+// Times and durations are printed in full, e.g. 2022-04-05T22:01:50Z and 2 days 1 hour.
+func PrintValue(value Value) string {
+	switch val := value.(type) {
+	case *StringValue:
+		return fmt.Sprintf(`"%v"`, printStringText(val.Val))
+	case *IntValue:
+		return strconv.Itoa(val.Val)
+	case *BoolValue:
+		return strconv.FormatBool(val.Val)
+	case *TimeValue:
+		return val.String()
+	case *DurationValue:
+		return val.String()
+	case *ArrayValue:
+		elems := make([]string, len(val.Vals))
+		for i, elem := range val.Vals {
+			elems[i] = PrintValue(elem)
+		}
+
+		return fmt.Sprintf("[%v]", strings.Join(elems, ", "))
+	case *RecordValue:
+		fields := make([]string, 0, len(val.Vals))
+		for field, fieldValue := range val.Vals {
+			fields = append(fields, fmt.Sprintf("%v: %v", field, PrintValue(fieldValue)))
+		}
+		sort.Strings(fields)
+
+		return fmt.Sprintf("{%v}", strings.Join(fields, ", "))
+	case *FunctionValue:
+		return "<function>"
+	}
+
+	return value.Kind()
+}
+
+func (u *UnaryOp) print() string {
+	return u.op.getOperator() + printOperand(u.expr, notPrecedence)
+}
+
+func (b *BinaryOp) print() string {
+	precedence := exprPrecedence(b)
+
+	// Binary operators are left associative.
+	lhs := printOperand(b.lhs, precedence)
+	rhs := printOperand(b.rhs, precedence+1)
+
+	return fmt.Sprintf("%v %v %v", lhs, b.op.getOperator(), rhs)
+}
+
+func (v *Variable) print() string {
+	return "$" + v.ident
+}
+
+func (b *BoolConst) print() string {
+	return strconv.FormatBool(b.value)
+}
+
+func (c *StringConst) print() string {
+	return fmt.Sprintf(`"%v"`, printStringText(c.value))
+}
+
+func (i *IntConst) print() string {
+	return strconv.Itoa(i.value)
+}
+
+func (t *TimeConst) print() string {
+	if t.relative != "" {
+		return t.relative
+	}
+
+	value := time.Unix(int64(t.value), 0).UTC()
+	if value.Hour() == 0 && value.Minute() == 0 && value.Second() == 0 {
+		return value.Format("2006-01-02")
+	}
+
+	return value.Format("2006-01-02T15:04:05")
+}
+
+// durationConstUnits are the units of duration literals from the largest to the smallest.
+var durationConstUnits = []string{"week", "day", "hour", "minute", "second"}
+
+func (d *DurationConst) print() string {
+	for _, unit := range durationConstUnits {
+		unitSeconds := durationUnitsSeconds[unit]
+		if d.value == 0 || d.value%unitSeconds != 0 {
+			continue
+		}
+
+		amount := d.value / unitSeconds
+		if amount == 1 {
+			return fmt.Sprintf("1 %v", unit)
+		}
+
+		return fmt.Sprintf("%v %vs", amount, unit)
+	}
+
+	return "0 seconds"
+}
+
+func (fc *FunctionCall) print() string {
+	return fmt.Sprintf("%v(%v)", fc.name.print(), printExprs(fc.arguments))
+}
+
+func (l *Lambda) print() string {
+	if len(l.parameters) == 0 {
+		return fmt.Sprintf("( => %v)", l.body.print())
+	}
+
+	return fmt.Sprintf("(%v => %v)", printExprs(l.parameters), l.body.print())
+}
+
+func (te *TypedExpr) print() string {
+	return fmt.Sprintf("%v: %v", printOperand(te.expr, atomPrecedence), printType(te.typeOf))
+}
+
+func (a *Array) print() string {
+	return fmt.Sprintf("[%v]", printExprs(a.elems))
+}
+
+func (l *Let) print() string {
+	return fmt.Sprintf("let %v = %v; %v", l.variable.print(), printOperand(l.value, orPrecedence), l.body.print())
+}
+
+func (c *Conditional) print() string {
+	condition := printOperand(c.condition, orPrecedence)
+	thenBranch := printOperand(c.thenBranch, orPrecedence)
+
+	return fmt.Sprintf("if %v then %v else %v", condition, thenBranch, c.elseBranch.print())
+}
+
+func (fa *FieldAccess) print() string {
+	return fmt.Sprintf("%v.%v", printOperand(fa.expr, fieldAccessPrecedence), fa.field)
+}
+
+func (i *Interpolation) print() string {
+	var sb strings.Builder
+
+	sb.WriteString(`"`)
+	for j, part := range i.parts {
+		if text, ok := part.(*StringConst); ok {
+			// A \ right before a placeholder would escape it, so it is written as a placeholder instead.
+			if j+1 < len(i.parts) && strings.HasSuffix(text.value, `\`) {
+				sb.WriteString(printStringText(strings.TrimSuffix(text.value, `\`)))
+				sb.WriteString(`${"\"}`)
+				continue
+			}
+
+			sb.WriteString(printStringText(text.value))
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("${%v}", part.print()))
+	}
+	sb.WriteString(`"`)
+
+	return sb.String()
+}
@@ -0,0 +1,155 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrint(t *testing.T) {
+	tests := map[string]struct {
+		input     string
+		wantPrint string
+	}{
+		"function call":                 {input: `$addLabel( "small" )`, wantPrint: `$addLabel("small")`},
+		"function call without args":    {input: `$author( )`, wantPrint: `$author()`},
+		"array":                         {input: `["a","b" ,"c"]`, wantPrint: `["a", "b", "c"]`},
+		"boolean operators":             {input: `$isDraft()&&!$hasLinkedIssues()||true`, wantPrint: `$isDraft() && !$hasLinkedIssues() || true`},
+		"redundant parentheses":         {input: `(($size() > 10) && ($size() < 100))`, wantPrint: `$size() > 10 && $size() < 100`},
+		"required parentheses":          {input: `(1 + 2) * 3 - (4 - 5)`, wantPrint: `(1 + 2) * 3 - (4 - 5)`},
+		"negated comparison":            {input: `!($size() > 10)`, wantPrint: `!($size() > 10)`},
+		"membership":                    {input: `$author() not  in ["john"]`, wantPrint: `$author() not in ["john"]`},
+		"regex match":                   {input: `$title() =~ "^feat"`, wantPrint: `$title() =~ "^feat"`},
+		"timestamp":                     {input: `$createdAt() < 20220405`, wantPrint: `$createdAt() < 2022-04-05`},
+		"timestamp with time":           {input: `$createdAt() < 2022-04-05T22:01:50`, wantPrint: `$createdAt() < 2022-04-05T22:01:50`},
+		"duration":                      {input: `$now() - 14 days`, wantPrint: `$now() - 2 weeks`},
+		"singular duration":             {input: `$now() - 60 minutes`, wantPrint: `$now() - 1 hour`},
+		"duration of thirty days":       {input: `$now() - 30 days`, wantPrint: `$now() - 30 days`},
+		"lambda":                        {input: `($a: Int,$b => $a>$b)`, wantPrint: `($a: Int, $b => $a > $b)`},
+		"lambda without parameters":     {input: `(=> 10)`, wantPrint: `( => 10)`},
+		"let":                           {input: `let $x = $size();$x > 10`, wantPrint: `let $x = $size(); $x > 10`},
+		"let as operand":                {input: `(let $x = 1; $x) + 1`, wantPrint: `(let $x = 1; $x) + 1`},
+		"conditional":                   {input: `if $isDraft() then "draft" else "ready"`, wantPrint: `if $isDraft() then "draft" else "ready"`},
+		"conditional in else branch":    {input: `if true then 1 else if false then 2 else 3`, wantPrint: `if true then 1 else if false then 2 else 3`},
+		"field access":                  {input: `$review.user`, wantPrint: `$review.user`},
+		"interpolation":                 {input: `"Hi @${author()}, ${1+1} \${x}"`, wantPrint: `"Hi @${$author()}, ${1 + 1} \${x}"`},
+		"escaped placeholder in string": {input: `"\${x}"`, wantPrint: `"\${x}"`},
+		"string literal placeholders":   {input: `"${"}"}${"a\"}${size()}"`, wantPrint: `"}a${"\"}${$size()}"`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotPrint := Print(expr)
+
+			assert.Equal(t, test.wantPrint, gotPrint)
+
+			reparsedExpr, err := Parse(gotPrint)
+			assert.Nil(t, err)
+			assert.True(t, expr.equals(reparsedExpr))
+		})
+	}
+}
+
+func TestPrint_RoundTrip(t *testing.T) {
+	size := BuildFunctionCall(BuildVariable("size"), []Expr{})
+	author := BuildFunctionCall(BuildVariable("author"), []Expr{})
+
+	tests := map[string]Expr{
+		"bool":                         BuildBoolConst(true),
+		"int":                          BuildIntConst(10),
+		"string":                       BuildStringConst("a } b ${c}"),
+		"time":                         BuildTimeConst("2022-04-05T22:01:50"),
+		"duration":                     BuildDurationConst("3 days"),
+		"variable":                     BuildVariable("x"),
+		"unary operator":               BuildNotOp(BuildOrOp(BuildBoolConst(true), BuildBoolConst(false))),
+		"binary operator":              BuildMulOp(BuildAddOp(BuildIntConst(1), BuildIntConst(2)), BuildSubOp(BuildIntConst(3), BuildIntConst(4))),
+		"function call":                BuildFunctionCall(BuildVariable("addLabel"), []Expr{BuildStringConst("small")}),
+		"array":                        BuildArray([]Expr{BuildStringConst("a"), BuildIntConst(1)}),
+		"typed expression":             BuildTypedExpr(BuildVariable("x"), BuildArrayOfType(BuildStringType())),
+		"lambda":                       BuildLambda([]Expr{BuildTypedExpr(BuildVariable("x"), BuildIntType())}, BuildGreaterThanOp(BuildVariable("x"), BuildIntConst(1))),
+		"let":                          BuildLet(BuildVariable("x"), size, BuildGreaterThanOp(BuildVariable("x"), BuildIntConst(10))),
+		"conditional":                  BuildConditional(BuildBoolConst(true), BuildIntConst(1), BuildIntConst(2)),
+		"field access":                 BuildFieldAccess(BuildVariable("review"), "user"),
+		"interpolation":                BuildInterpolation([]Expr{BuildStringConst("Hi @"), author, BuildStringConst("!")}),
+		"nested string with brace":     BuildInterpolation([]Expr{BuildConditional(BuildBoolConst(true), BuildStringConst("}"), BuildStringConst("{"))}),
+		"nested interpolation":         BuildInterpolation([]Expr{BuildStringConst("a"), BuildInterpolation([]Expr{BuildStringConst("} "), size})}),
+		"backslash before placeholder": BuildInterpolation([]Expr{BuildStringConst(`a\`), size}),
+		"escaped placeholder":          BuildInterpolation([]Expr{BuildStringConst("${x} "), size}),
+	}
+
+	for name, expr := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotExpr, err := Parse(Print(expr))
+
+			assert.Nil(t, err)
+			assert.True(t, expr.equals(gotExpr), Print(expr))
+		})
+	}
+}
+
+func TestPrint_WhenRelativeTime(t *testing.T) {
+	expr, err := Parse("$createdAt() < 3\tdays ago")
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	assert.Equal(t, `$createdAt() < 3 days ago`, Print(expr))
+}
+
+func TestPrintType(t *testing.T) {
+	assert.Equal(t, "[]Duration", printType(BuildArrayOfType(BuildDurationType())))
+	assert.Equal(t, "Time", printType(BuildTimeType()))
+}
+
+func TestPrintType_WhenTypeCannotBeWritten(t *testing.T) {
+	assert.Equal(t, "[String, Int]", PrintType(BuildArrayType([]Type{BuildStringType(), BuildIntType()})))
+	assert.Equal(t, "{login: String, size: Int}", PrintType(BuildRecordType(map[string]Type{"size": BuildIntType(), "login": BuildStringType()})))
+	assert.Equal(t, "([]A, (A) => Bool) => []A", PrintType(BuildFunctionType(
+		[]Type{BuildArrayOfType(BuildTypeVariable("A")), BuildFunctionType([]Type{BuildTypeVariable("A")}, BuildBoolType())},
+		BuildArrayOfType(BuildTypeVariable("A")),
+	)))
+	assert.Equal(t, "(String) => Unit", PrintType(BuildFunctionType([]Type{BuildStringType()}, nil)))
+}
+
+func TestPrintValue(t *testing.T) {
+	tests := map[string]struct {
+		value     Value
+		wantPrint string
+	}{
+		"string": {
+			value:     BuildStringValue("${john}"),
+			wantPrint: `"\${john}"`,
+		},
+		"time": {
+			value:     BuildTimeValue(1649196110),
+			wantPrint: "2022-04-05T22:01:50Z",
+		},
+		"duration": {
+			value:     BuildDurationValue(2*24*60*60 + 60*60),
+			wantPrint: "2 days 1 hour",
+		},
+		"array": {
+			value:     BuildArrayValue([]Value{BuildIntValue(1), BuildTrueValue()}),
+			wantPrint: "[1, true]",
+		},
+		"record": {
+			value:     BuildRecordValue(map[string]Value{"size": BuildIntValue(1), "login": BuildStringValue("john")}),
+			wantPrint: `{login: "john", size: 1}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.wantPrint, PrintValue(test.value))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"fmt"
+
+	"github.com/reviewpad/reviewpad/v3/engine"
+)
+
+// Inspect evaluates an expression typed in the REPL and describes its value and its type,
+// e.g. $reviewerStatus("john") gives "APPROVED" : String.
+// Calls to actions are executed like the actions of the workflows, so they are not executed in dry run.
+func (i *Interpreter) Inspect(input string) (string, error) {
+	expr, exprSourceMap, err := parse(input)
+	if err != nil {
+		return "", err
+	}
+
+	if fc, ok := expr.(*FunctionCall); ok {
+		if _, isAction := i.Env.GetBuiltIns().Actions[fc.name.ident]; isAction {
+			return i.inspectAction(input)
+		}
+	}
+
+	exprType, err := TypeInference(i.Env, expr)
+	if err != nil {
+		return "", exprSourceMap.locate(err)
+	}
+
+	value, err := Eval(i.Env, expr)
+	if err != nil {
+		return "", exprSourceMap.locate(err)
+	}
+
+	return fmt.Sprintf("%v : %v", PrintValue(value), PrintType(exprType)), nil
+}
+
+func (i *Interpreter) inspectAction(input string) (string, error) {
+	if err := i.ExecStatement(engine.BuildStatement(input)); err != nil {
+		return "", err
+	}
+
+	if i.Env.GetDryRun() {
+		return "", fmt.Errorf("action %v was not executed since actions are not allowed in dry run", input)
+	}
+
+	return fmt.Sprintf("action %v executed", input), nil
+}
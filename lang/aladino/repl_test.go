@@ -0,0 +1,91 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect(t *testing.T) {
+	tests := map[string]struct {
+		input      string
+		wantResult string
+	}{
+		"condition": {
+			input:      "$zeroConst() == 0",
+			wantResult: "true : Bool",
+		},
+		"string": {
+			input:      `$returnStr("john")`,
+			wantResult: `"john" : String`,
+		},
+		"array": {
+			input:      `[$returnStr("john"), "jane"]`,
+			wantResult: `["john", "jane"] : [String, String]`,
+		},
+		"duration": {
+			input:      "2 days",
+			wantResult: "2 days : Duration",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockedInterpreter := &aladino.Interpreter{
+				Env: aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil),
+			}
+
+			gotResult, err := mockedInterpreter.Inspect(test.input)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantResult, gotResult)
+		})
+	}
+}
+
+func TestInspect_WhenExpressionIsInvalid(t *testing.T) {
+	mockedInterpreter := &aladino.Interpreter{
+		Env: aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil),
+	}
+
+	_, err := mockedInterpreter.Inspect(`$zeroConst() == "0"`)
+
+	assert.ErrorContains(t, err, "type inference failed")
+}
+
+func TestInspect_WhenActionsAreAllowed(t *testing.T) {
+	mockedInterpreter := &aladino.Interpreter{
+		Env: aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil),
+	}
+
+	gotResult, err := mockedInterpreter.Inspect("$emptyAction()")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "action $emptyAction() executed", gotResult)
+}
+
+func TestInspect_WhenActionsAreNotAllowed(t *testing.T) {
+	actionCalled := false
+	builtIns := aladino.MockBuiltIns()
+	builtIns.Actions["emptyAction"].Code = func(e aladino.Env, args []aladino.Value) error {
+		actionCalled = true
+		return nil
+	}
+
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, builtIns, nil)
+	mockedEnv.(*aladino.BaseEnv).DryRun = true
+
+	mockedInterpreter := &aladino.Interpreter{
+		Env: mockedEnv,
+	}
+
+	_, err := mockedInterpreter.Inspect("$emptyAction()")
+
+	assert.EqualError(t, err, "action $emptyAction() was not executed since actions are not allowed in dry run")
+	assert.False(t, actionCalled)
+}
@@ -0,0 +1,172 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import "math"
+
+// intComparison is the comparison of an Int expression with a constant, e.g. $size() > 10.
+type intComparison struct {
+	operand Expr
+	op      string
+	value   int
+}
+
+// intRange is the range of values an Int expression can take, bounds included.
+type intRange struct {
+	min, max int
+	// excluded are the values the expression cannot take, e.g. 5 in $size() != 5.
+	excluded []int
+}
+
+// conditionValue tells if a well typed condition is always true or always false.
+// Besides constant conditions, it detects the conjunctions of comparisons that cannot hold,
+// e.g. $size() > 10 && $size() < 5, and the disjunctions of comparisons that always hold,
+// e.g. $size() > 10 || $size() <= 10.
+func conditionValue(condition Expr) (bool, bool) {
+	folded := Fold(condition)
+	if boolConst, ok := folded.(*BoolConst); ok {
+		return boolConst.value, true
+	}
+
+	if !areSatisfiable(buildIntComparisons(conjuncts(folded), false)) {
+		return false, true
+	}
+
+	// A disjunction always holds when the negation of its comparisons cannot hold.
+	if !areSatisfiable(buildIntComparisons(disjuncts(folded), true)) {
+		return true, true
+	}
+
+	return false, false
+}
+
+func conjuncts(expr Expr) []Expr {
+	if b, ok := expr.(*BinaryOp); ok && b.op.getOperator() == AND_OP {
+		return append(conjuncts(b.lhs), conjuncts(b.rhs)...)
+	}
+
+	return []Expr{expr}
+}
+
+func disjuncts(expr Expr) []Expr {
+	if b, ok := expr.(*BinaryOp); ok && b.op.getOperator() == OR_OP {
+		return append(disjuncts(b.lhs), disjuncts(b.rhs)...)
+	}
+
+	return []Expr{expr}
+}
+
+// buildIntComparisons picks the comparisons of Int expressions with constants, negated if asked to.
+// The constant is moved to the right, e.g. 10 < $size() becomes $size() > 10.
+func buildIntComparisons(exprs []Expr, negate bool) []intComparison {
+	comparisons := make([]intComparison, 0)
+	for _, expr := range exprs {
+		b, ok := expr.(*BinaryOp)
+		if !ok {
+			continue
+		}
+
+		op := b.op.getOperator()
+		operand, value := b.lhs, b.rhs
+		if _, isIntConst := operand.(*IntConst); isIntConst {
+			operand, value = value, operand
+			op = mirroredOperators[op]
+		}
+
+		intConst, isIntConst := value.(*IntConst)
+		if !isIntConst || isConst(operand) {
+			continue
+		}
+
+		if negate {
+			op = negatedOperators[op]
+		}
+
+		if op != "" {
+			comparisons = append(comparisons, intComparison{operand, op, intConst.value})
+		}
+	}
+
+	return comparisons
+}
+
+// mirroredOperators are the operators for swapped operands, e.g. 10 < $size() is $size() > 10.
+var mirroredOperators = map[string]string{
+	EQ_OP:              EQ_OP,
+	NEQ_OP:             NEQ_OP,
+	LESS_THAN_OP:       GREATER_THAN_OP,
+	LESS_EQ_THAN_OP:    GREATER_EQ_THAN_OP,
+	GREATER_THAN_OP:    LESS_THAN_OP,
+	GREATER_EQ_THAN_OP: LESS_EQ_THAN_OP,
+}
+
+var negatedOperators = map[string]string{
+	EQ_OP:              NEQ_OP,
+	NEQ_OP:             EQ_OP,
+	LESS_THAN_OP:       GREATER_EQ_THAN_OP,
+	LESS_EQ_THAN_OP:    GREATER_THAN_OP,
+	GREATER_THAN_OP:    LESS_EQ_THAN_OP,
+	GREATER_EQ_THAN_OP: LESS_THAN_OP,
+}
+
+// areSatisfiable tells if the comparisons can hold at the same time.
+// The operands are compared by their source, e.g. both $size() in $size() > 10 && $size() < 5 are the same.
+func areSatisfiable(comparisons []intComparison) bool {
+	ranges := make(map[string]*intRange)
+	for _, comparison := range comparisons {
+		operand := Print(comparison.operand)
+		r, ok := ranges[operand]
+		if !ok {
+			r = &intRange{min: math.MinInt, max: math.MaxInt}
+			ranges[operand] = r
+		}
+
+		switch comparison.op {
+		case EQ_OP:
+			r.min = maxInt(r.min, comparison.value)
+			r.max = minInt(r.max, comparison.value)
+		case NEQ_OP:
+			r.excluded = append(r.excluded, comparison.value)
+		case LESS_THAN_OP:
+			r.max = minInt(r.max, comparison.value-1)
+		case LESS_EQ_THAN_OP:
+			r.max = minInt(r.max, comparison.value)
+		case GREATER_THAN_OP:
+			r.min = maxInt(r.min, comparison.value+1)
+		case GREATER_EQ_THAN_OP:
+			r.min = maxInt(r.min, comparison.value)
+		}
+	}
+
+	for _, r := range ranges {
+		if r.min > r.max {
+			return false
+		}
+
+		if r.min == r.max {
+			for _, excluded := range r.excluded {
+				if excluded == r.min {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionValue(t *testing.T) {
+	tests := map[string]struct {
+		input          string
+		wantValue      bool
+		wantIsConstant bool
+	}{
+		"true":                       {input: "true", wantValue: true, wantIsConstant: true},
+		"constant comparison":        {input: "1 > 2", wantValue: false, wantIsConstant: true},
+		"disjoint ranges":            {input: "$size() > 10 && $size() < 5", wantValue: false, wantIsConstant: true},
+		"constant on the left":       {input: "10 < $size() && 5 > $size()", wantValue: false, wantIsConstant: true},
+		"equal to excluded value":    {input: `$size() == 1 && $isDraft() && $size() != 1`, wantValue: false, wantIsConstant: true},
+		"complementary ranges":       {input: "$size() > 10 || $size() <= 10", wantValue: true, wantIsConstant: true},
+		"different or equal":         {input: "$size() != 3 || $size() == 3", wantValue: true, wantIsConstant: true},
+		"overlapping ranges":         {input: "$size() > 5 && $size() < 10", wantIsConstant: false},
+		"ranges of different values": {input: "$size() > 10 && $fileCount() < 5", wantIsConstant: false},
+		"incomplete ranges":          {input: "$size() > 10 || $size() < 10", wantIsConstant: false},
+		"not a comparison":           {input: "$isDraft()", wantIsConstant: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotValue, gotIsConstant := conditionValue(expr)
+
+			assert.Equal(t, test.wantIsConstant, gotIsConstant)
+			if test.wantIsConstant {
+				assert.Equal(t, test.wantValue, gotValue)
+			}
+		})
+	}
+}
@@ -16,6 +16,10 @@ const (
 	FUNCTION_TYPE string = "FunctionType"
 	ARRAY_TYPE    string = "ArrayType"
 	ARRAY_OF_TYPE string = "ArrayOfType"
+	RECORD_TYPE   string = "RecordType"
+	TIME_TYPE     string = "TimeType"
+	DURATION_TYPE string = "DurationType"
+	TYPE_VARIABLE string = "TypeVariable"
 )
 
 type StringType struct{}
@@ -24,9 +28,19 @@ type IntType struct{}
 
 type BoolType struct{}
 
+// TimeType is the type of instants in time, e.g. 2022-04-05 or 3 days ago
+type TimeType struct{}
+
+// DurationType is the type of amounts of time, e.g. 2 days
+type DurationType struct{}
+
+// FunctionType is the type of built-ins.
+// The last parameters of a built-in can be optional, in which case the default values are used when they are omitted.
+// e.g. the type of $merge is (String) => Bool where the merge method defaults to "merge"
 type FunctionType struct {
-	paramTypes []Type
-	returnType Type
+	paramTypes    []Type
+	returnType    Type
+	defaultValues []Value
 }
 
 type ArrayOfType struct {
@@ -38,12 +52,31 @@ type ArrayType struct {
 	elemsType []Type
 }
 
-func BuildStringType() *StringType { return &StringType{} }
-func BuildIntType() *IntType       { return &IntType{} }
-func BuildBoolType() *BoolType     { return &BoolType{} }
+type RecordType struct {
+	fieldsType map[string]Type
+}
+
+// TypeVariable stands for any type in the signature of generic built-ins.
+// e.g. the type of $map is ([]A, (A) => B) => []B
+// A type variable can be restricted to types of some kinds, e.g. the elements of $sort must be ordered.
+type TypeVariable struct {
+	name  string
+	kinds []string
+}
+
+func BuildStringType() *StringType     { return &StringType{} }
+func BuildIntType() *IntType           { return &IntType{} }
+func BuildBoolType() *BoolType         { return &BoolType{} }
+func BuildTimeType() *TimeType         { return &TimeType{} }
+func BuildDurationType() *DurationType { return &DurationType{} }
 
 func BuildFunctionType(paramsTypes []Type, returnType Type) *FunctionType {
-	return &FunctionType{paramsTypes, returnType}
+	return &FunctionType{paramsTypes, returnType, nil}
+}
+
+// BuildFunctionTypeWithDefaults builds the type of a function whose last len(defaultValues) parameters are optional.
+func BuildFunctionTypeWithDefaults(paramsTypes []Type, returnType Type, defaultValues []Value) *FunctionType {
+	return &FunctionType{paramsTypes, returnType, defaultValues}
 }
 
 func BuildArrayOfType(elemType Type) *ArrayOfType {
@@ -54,6 +87,34 @@ func BuildArrayType(elemsTypes []Type) *ArrayType {
 	return &ArrayType{elemsTypes}
 }
 
+func BuildRecordType(fieldsTypes map[string]Type) *RecordType {
+	return &RecordType{fieldsTypes}
+}
+
+func BuildTypeVariable(name string) *TypeVariable {
+	return &TypeVariable{name: name}
+}
+
+// BuildRestrictedTypeVariable builds a type variable that only stands for types of the given kinds.
+func BuildRestrictedTypeVariable(name string, kinds []string) *TypeVariable {
+	return &TypeVariable{name: name, kinds: kinds}
+}
+
+// admits checks if the type variable can stand for the type.
+func (vTy *TypeVariable) admits(ty Type) bool {
+	if vTy.kinds == nil {
+		return true
+	}
+
+	for _, kind := range vTy.kinds {
+		if ty.Kind() == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (bTy *BoolType) Kind() string {
 	return BOOL_TYPE
 }
@@ -66,6 +127,14 @@ func (sTy *StringType) Kind() string {
 	return STRING_TYPE
 }
 
+func (tTy *TimeType) Kind() string {
+	return TIME_TYPE
+}
+
+func (dTy *DurationType) Kind() string {
+	return DURATION_TYPE
+}
+
 func (fTy *FunctionType) Kind() string {
 	return FUNCTION_TYPE
 }
@@ -78,6 +147,14 @@ func (aTy *ArrayOfType) Kind() string {
 	return ARRAY_OF_TYPE
 }
 
+func (rTy *RecordType) Kind() string {
+	return RECORD_TYPE
+}
+
+func (vTy *TypeVariable) Kind() string {
+	return TYPE_VARIABLE
+}
+
 // Equals
 // equals on arrays
 func equals(leftTys []Type, rightTys []Type) bool {
@@ -108,6 +185,14 @@ func (thisTy *IntType) equals(thatTy Type) bool {
 	return thatTy.Kind() == thisTy.Kind()
 }
 
+func (thisTy *TimeType) equals(thatTy Type) bool {
+	return thatTy.Kind() == thisTy.Kind()
+}
+
+func (thisTy *DurationType) equals(thatTy Type) bool {
+	return thatTy.Kind() == thisTy.Kind()
+}
+
 func (thisTy *FunctionType) equals(thatTy Type) bool {
 	if thisTy.Kind() != thatTy.Kind() {
 		return false
@@ -146,3 +231,196 @@ func (thisTy *ArrayOfType) equals(thatTy Type) bool {
 	}
 	return false
 }
+
+func (thisTy *RecordType) equals(thatTy Type) bool {
+	if thisTy.Kind() != thatTy.Kind() {
+		return false
+	}
+
+	thatTyRecord := thatTy.(*RecordType)
+	if len(thisTy.fieldsType) != len(thatTyRecord.fieldsType) {
+		return false
+	}
+
+	for field, fieldTy := range thisTy.fieldsType {
+		thatFieldTy, ok := thatTyRecord.fieldsType[field]
+		if !ok || !fieldTy.equals(thatFieldTy) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (thisTy *TypeVariable) equals(thatTy Type) bool {
+	if thisTy.Kind() != thatTy.Kind() {
+		return false
+	}
+
+	return thisTy.name == thatTy.(*TypeVariable).name
+}
+
+// unify returns the most general type that both types can take.
+// Arrays with different lengths unify into an array of a common element type.
+func unify(leftTy Type, rightTy Type) (Type, bool) {
+	leftElemsTy, isLeftArray := arrayElemsTypes(leftTy)
+	rightElemsTy, isRightArray := arrayElemsTypes(rightTy)
+
+	if !isLeftArray || !isRightArray {
+		if leftTy.equals(rightTy) {
+			return leftTy, true
+		}
+
+		return nil, false
+	}
+
+	if leftTy.Kind() == ARRAY_TYPE && rightTy.Kind() == ARRAY_TYPE && leftTy.equals(rightTy) {
+		return leftTy, true
+	}
+
+	elemsTy := make([]Type, 0, len(leftElemsTy)+len(rightElemsTy))
+	elemsTy = append(elemsTy, leftElemsTy...)
+	elemsTy = append(elemsTy, rightElemsTy...)
+
+	var elemTy Type
+	for _, ty := range elemsTy {
+		if elemTy == nil {
+			elemTy = ty
+			continue
+		}
+
+		unifiedTy, ok := unify(elemTy, ty)
+		if !ok {
+			return nil, false
+		}
+
+		elemTy = unifiedTy
+	}
+
+	if elemTy == nil {
+		return leftTy, true
+	}
+
+	return BuildArrayOfType(elemTy), true
+}
+
+func arrayElemsTypes(ty Type) ([]Type, bool) {
+	switch ty.Kind() {
+	case ARRAY_TYPE:
+		return ty.(*ArrayType).elemsType, true
+	case ARRAY_OF_TYPE:
+		return []Type{ty.(*ArrayOfType).elemType}, true
+	}
+
+	return nil, false
+}
+
+// matchType checks if a value of type actualTy can be used where a value of type expectedTy is required.
+// The type variables of expectedTy are bound in subst as they are matched.
+func matchType(expectedTy Type, actualTy Type, subst map[string]Type) bool {
+	switch ty := expectedTy.(type) {
+	case *TypeVariable:
+		if !ty.admits(actualTy) {
+			return false
+		}
+
+		boundTy, isBound := subst[ty.name]
+		if !isBound {
+			subst[ty.name] = actualTy
+			return true
+		}
+
+		unifiedTy, ok := unify(boundTy, actualTy)
+		if !ok {
+			return false
+		}
+
+		subst[ty.name] = unifiedTy
+		return true
+	case *ArrayOfType:
+		elemsTy, isArray := arrayElemsTypes(actualTy)
+		if !isArray {
+			return false
+		}
+
+		for _, elemTy := range elemsTy {
+			if !matchType(ty.elemType, elemTy, subst) {
+				return false
+			}
+		}
+
+		return true
+	case *FunctionType:
+		actualFnTy, ok := actualTy.(*FunctionType)
+		if !ok || len(ty.paramTypes) != len(actualFnTy.paramTypes) {
+			return false
+		}
+
+		for i, paramTy := range ty.paramTypes {
+			if !matchType(paramTy, actualFnTy.paramTypes[i], subst) {
+				return false
+			}
+		}
+
+		return matchType(ty.returnType, actualFnTy.returnType, subst)
+	}
+
+	return actualTy.equals(expectedTy)
+}
+
+// substitute replaces the type variables of ty that are bound in subst.
+func substitute(ty Type, subst map[string]Type) Type {
+	switch t := ty.(type) {
+	case *TypeVariable:
+		if boundTy, ok := subst[t.name]; ok {
+			return boundTy
+		}
+	case *ArrayOfType:
+		return BuildArrayOfType(substitute(t.elemType, subst))
+	case *FunctionType:
+		paramsTy := make([]Type, len(t.paramTypes))
+		for i, paramTy := range t.paramTypes {
+			paramsTy[i] = substitute(paramTy, subst)
+		}
+
+		return BuildFunctionTypeWithDefaults(paramsTy, substitute(t.returnType, subst), t.defaultValues)
+	}
+
+	return ty
+}
+
+// defaultTypeVariables gives the type of the empty array to the arrays of ty whose element type is not bound.
+// Their elements can only come from empty arrays, e.g. in $unique([]), so they are empty.
+func defaultTypeVariables(ty Type) Type {
+	if t, ok := ty.(*ArrayOfType); ok && hasTypeVariables(t.elemType) {
+		return BuildArrayType([]Type{})
+	}
+
+	return ty
+}
+
+// hasTypeVariables checks if ty has type variables that are not bound.
+func hasTypeVariables(ty Type) bool {
+	switch t := ty.(type) {
+	case *TypeVariable:
+		return true
+	case *ArrayOfType:
+		return hasTypeVariables(t.elemType)
+	case *ArrayType:
+		for _, elemTy := range t.elemsType {
+			if hasTypeVariables(elemTy) {
+				return true
+			}
+		}
+	case *FunctionType:
+		for _, paramTy := range t.paramTypes {
+			if hasTypeVariables(paramTy) {
+				return true
+			}
+		}
+
+		return hasTypeVariables(t.returnType)
+	}
+
+	return false
+}
@@ -32,12 +32,19 @@ func TestBuildBoolType(t *testing.T) {
 }
 
 func TestBuildFunctionType(t *testing.T) {
-	wantVal := &FunctionType{[]Type{&StringType{}}, &StringType{}}
+	wantVal := &FunctionType{[]Type{&StringType{}}, &StringType{}, nil}
 	gotVal := BuildFunctionType([]Type{&StringType{}}, &StringType{})
 
 	assert.Equal(t, wantVal, gotVal)
 }
 
+func TestBuildFunctionTypeWithDefaults(t *testing.T) {
+	wantVal := &FunctionType{[]Type{&StringType{}, &IntType{}}, &StringType{}, []Value{BuildIntValue(1)}}
+	gotVal := BuildFunctionTypeWithDefaults([]Type{&StringType{}, &IntType{}}, &StringType{}, []Value{BuildIntValue(1)})
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
 func TestBuildArrayOfType(t *testing.T) {
 	wantVal := &ArrayOfType{&StringType{}}
 	gotVal := BuildArrayOfType(&StringType{})
@@ -240,3 +247,268 @@ func TestEquals_WhenArrayOfTypeComparedToStringType(t *testing.T) {
 
 	assert.False(t, arrayOfType.equals(otherType))
 }
+
+func TestBuildRecordType(t *testing.T) {
+	wantVal := &RecordType{map[string]Type{"user": &StringType{}}}
+	gotVal := BuildRecordType(map[string]Type{"user": BuildStringType()})
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestKind_WhenRecordType(t *testing.T) {
+	wantVal := RECORD_TYPE
+	gotVal := BuildRecordType(map[string]Type{}).Kind()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEquals_WhenRecordTypesHaveDiffFields(t *testing.T) {
+	recordType := BuildRecordType(map[string]Type{"user": BuildStringType()})
+	otherRecordType := BuildRecordType(map[string]Type{"user": BuildStringType(), "state": BuildStringType()})
+
+	assert.False(t, recordType.equals(otherRecordType))
+}
+
+func TestEquals_WhenRecordTypesHaveDiffFieldTypes(t *testing.T) {
+	recordType := BuildRecordType(map[string]Type{"user": BuildStringType()})
+	otherRecordType := BuildRecordType(map[string]Type{"user": BuildIntType()})
+
+	assert.False(t, recordType.equals(otherRecordType))
+}
+
+func TestEquals_WhenRecordTypesAreEqual(t *testing.T) {
+	recordType := BuildRecordType(map[string]Type{"user": BuildStringType()})
+	otherRecordType := BuildRecordType(map[string]Type{"user": BuildStringType()})
+
+	assert.True(t, recordType.equals(otherRecordType))
+}
+
+func TestEquals_WhenRecordTypeComparedToStringType(t *testing.T) {
+	recordType := BuildRecordType(map[string]Type{"user": BuildStringType()})
+
+	assert.False(t, recordType.equals(BuildStringType()))
+}
+
+func TestUnify(t *testing.T) {
+	tests := map[string]struct {
+		leftTy   Type
+		rightTy  Type
+		wantTy   Type
+		wantUnif bool
+	}{
+		"same basic types": {
+			leftTy:   BuildIntType(),
+			rightTy:  BuildIntType(),
+			wantTy:   BuildIntType(),
+			wantUnif: true,
+		},
+		"different basic types": {
+			leftTy:   BuildIntType(),
+			rightTy:  BuildStringType(),
+			wantUnif: false,
+		},
+		"arrays with same length": {
+			leftTy:   BuildArrayType([]Type{BuildIntType()}),
+			rightTy:  BuildArrayType([]Type{BuildIntType()}),
+			wantTy:   BuildArrayType([]Type{BuildIntType()}),
+			wantUnif: true,
+		},
+		"arrays with different length": {
+			leftTy:   BuildArrayType([]Type{BuildIntType()}),
+			rightTy:  BuildArrayType([]Type{BuildIntType(), BuildIntType()}),
+			wantTy:   BuildArrayOfType(BuildIntType()),
+			wantUnif: true,
+		},
+		"empty array and array of type": {
+			leftTy:   BuildArrayType([]Type{}),
+			rightTy:  BuildArrayOfType(BuildStringType()),
+			wantTy:   BuildArrayOfType(BuildStringType()),
+			wantUnif: true,
+		},
+		"arrays with different element types": {
+			leftTy:   BuildArrayType([]Type{BuildIntType()}),
+			rightTy:  BuildArrayOfType(BuildStringType()),
+			wantUnif: false,
+		},
+		"array and basic type": {
+			leftTy:   BuildArrayOfType(BuildIntType()),
+			rightTy:  BuildIntType(),
+			wantUnif: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotTy, gotUnif := unify(test.leftTy, test.rightTy)
+
+			assert.Equal(t, test.wantUnif, gotUnif)
+			assert.Equal(t, test.wantTy, gotTy)
+		})
+	}
+}
+
+func TestBuildTimeType(t *testing.T) {
+	wantVal := &TimeType{}
+	gotVal := BuildTimeType()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestBuildDurationType(t *testing.T) {
+	wantVal := &DurationType{}
+	gotVal := BuildDurationType()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestKind_WhenTimeType(t *testing.T) {
+	wantVal := TIME_TYPE
+	gotVal := BuildTimeType().Kind()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestKind_WhenDurationType(t *testing.T) {
+	wantVal := DURATION_TYPE
+	gotVal := BuildDurationType().Kind()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEquals_WhenTimeTypeComparedToDurationType(t *testing.T) {
+	assert.False(t, BuildTimeType().equals(BuildDurationType()))
+}
+
+func TestEquals_WhenDurationTypeComparedToSameType(t *testing.T) {
+	assert.True(t, BuildDurationType().equals(BuildDurationType()))
+}
+
+func TestBuildTypeVariable(t *testing.T) {
+	wantVal := &TypeVariable{name: "A"}
+	gotVal := BuildTypeVariable("A")
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestBuildRestrictedTypeVariable(t *testing.T) {
+	wantVal := &TypeVariable{name: "A", kinds: []string{INT_TYPE}}
+	gotVal := BuildRestrictedTypeVariable("A", []string{INT_TYPE})
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestKind_WhenTypeVariable(t *testing.T) {
+	wantVal := TYPE_VARIABLE
+	gotVal := BuildTypeVariable("A").Kind()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestEquals_WhenTypeVariablesHaveDiffNames(t *testing.T) {
+	assert.False(t, BuildTypeVariable("A").equals(BuildTypeVariable("B")))
+}
+
+func TestEquals_WhenTypeVariableComparedToStringType(t *testing.T) {
+	assert.False(t, BuildTypeVariable("A").equals(BuildStringType()))
+}
+
+func TestMatchType(t *testing.T) {
+	tests := map[string]struct {
+		expectedTy Type
+		actualTy   Type
+		wantMatch  bool
+		wantSubst  map[string]Type
+	}{
+		"same basic types": {
+			expectedTy: BuildIntType(),
+			actualTy:   BuildIntType(),
+			wantMatch:  true,
+			wantSubst:  map[string]Type{},
+		},
+		"different basic types": {
+			expectedTy: BuildIntType(),
+			actualTy:   BuildStringType(),
+			wantMatch:  false,
+			wantSubst:  map[string]Type{},
+		},
+		"type variable": {
+			expectedTy: BuildTypeVariable("A"),
+			actualTy:   BuildStringType(),
+			wantMatch:  true,
+			wantSubst:  map[string]Type{"A": BuildStringType()},
+		},
+		"restricted type variable": {
+			expectedTy: BuildRestrictedTypeVariable("A", []string{INT_TYPE, STRING_TYPE}),
+			actualTy:   BuildStringType(),
+			wantMatch:  true,
+			wantSubst:  map[string]Type{"A": BuildStringType()},
+		},
+		"restricted type variable and type of other kind": {
+			expectedTy: BuildArrayOfType(BuildRestrictedTypeVariable("A", []string{INT_TYPE, STRING_TYPE})),
+			actualTy:   BuildArrayType([]Type{BuildBoolType()}),
+			wantMatch:  false,
+			wantSubst:  map[string]Type{},
+		},
+		"array of type variable": {
+			expectedTy: BuildArrayOfType(BuildTypeVariable("A")),
+			actualTy:   BuildArrayType([]Type{BuildIntType(), BuildIntType()}),
+			wantMatch:  true,
+			wantSubst:  map[string]Type{"A": BuildIntType()},
+		},
+		"array of type variable with different element types": {
+			expectedTy: BuildArrayOfType(BuildTypeVariable("A")),
+			actualTy:   BuildArrayType([]Type{BuildIntType(), BuildStringType()}),
+			wantMatch:  false,
+			wantSubst:  map[string]Type{"A": BuildIntType()},
+		},
+		"array of type variable and basic type": {
+			expectedTy: BuildArrayOfType(BuildTypeVariable("A")),
+			actualTy:   BuildStringType(),
+			wantMatch:  false,
+			wantSubst:  map[string]Type{},
+		},
+		"function type": {
+			expectedTy: BuildFunctionType([]Type{BuildTypeVariable("A")}, BuildTypeVariable("B")),
+			actualTy:   BuildFunctionType([]Type{BuildStringType()}, BuildBoolType()),
+			wantMatch:  true,
+			wantSubst:  map[string]Type{"A": BuildStringType(), "B": BuildBoolType()},
+		},
+		"function types with different number of parameters": {
+			expectedTy: BuildFunctionType([]Type{BuildTypeVariable("A")}, BuildBoolType()),
+			actualTy:   BuildFunctionType([]Type{}, BuildBoolType()),
+			wantMatch:  false,
+			wantSubst:  map[string]Type{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotSubst := map[string]Type{}
+			gotMatch := matchType(test.expectedTy, test.actualTy, gotSubst)
+
+			assert.Equal(t, test.wantMatch, gotMatch)
+			assert.Equal(t, test.wantSubst, gotSubst)
+		})
+	}
+}
+
+func TestSubstitute(t *testing.T) {
+	subst := map[string]Type{"A": BuildStringType()}
+	ty := BuildFunctionType(
+		[]Type{BuildArrayOfType(BuildTypeVariable("A"))},
+		BuildTypeVariable("B"),
+	)
+
+	wantTy := BuildFunctionType(
+		[]Type{BuildArrayOfType(BuildStringType())},
+		BuildTypeVariable("B"),
+	)
+	gotTy := substitute(ty, subst)
+
+	assert.Equal(t, wantTy, gotTy)
+}
+
+func TestHasTypeVariables(t *testing.T) {
+	assert.True(t, hasTypeVariables(BuildFunctionType([]Type{}, BuildArrayOfType(BuildTypeVariable("A")))))
+	assert.False(t, hasTypeVariables(BuildFunctionType([]Type{BuildIntType()}, BuildArrayOfType(BuildStringType()))))
+}
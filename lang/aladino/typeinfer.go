@@ -4,7 +4,10 @@
 
 package aladino
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+)
 
 func TypeInference(e Env, expr Expr) (Type, error) {
 	return expr.typeinfer(NewTypeEnv(e))
@@ -36,7 +39,7 @@ func (u *UnaryOp) typeinfer(env TypeEnv) (Type, error) {
 			return BuildBoolType(), nil
 		}
 	}
-	return nil, fmt.Errorf("type inference failed")
+	return nil, exprErrorf(u, "type inference failed")
 }
 
 func (b *BinaryOp) typeinfer(env TypeEnv) (Type, error) {
@@ -56,44 +59,123 @@ func (b *BinaryOp) typeinfer(env TypeEnv) (Type, error) {
 			return BuildBoolType(), nil
 		}
 	case GREATER_EQ_THAN_OP, GREATER_THAN_OP, LESS_EQ_THAN_OP, LESS_THAN_OP:
-		if lhsType.equals(BuildIntType()) && rhsType.equals(BuildIntType()) {
+		if isOrdered(lhsType) && lhsType.equals(rhsType) {
 			return BuildBoolType(), nil
 		}
 	case AND_OP, OR_OP:
 		if lhsType.equals(BuildBoolType()) && rhsType.equals(BuildBoolType()) {
+			return BuildBoolType(), nil
+		}
+	case ADD_OP, SUB_OP, MUL_OP, DIV_OP, MOD_OP:
+		if resultType, ok := arithmeticType(b.op.getOperator(), lhsType, rhsType); ok {
+			return resultType, nil
+		}
+	case IN_OP, NOT_IN_OP:
+		if isElementType(lhsType, rhsType) {
+			return BuildBoolType(), nil
+		}
+	case MATCH_OP:
+		if lhsType.equals(BuildStringType()) && rhsType.equals(BuildStringType()) {
+			// Constant patterns are checked here so invalid regexes are reported before evaluation.
+			if err := checkRegex(b.rhs); err != nil {
+				return nil, exprErrorf(b.rhs, "type inference failed: %v", err)
+			}
+
 			return BuildBoolType(), nil
 		}
 	}
 
-	return nil, fmt.Errorf("type inference failed")
+	return nil, exprErrorf(b, "type inference failed")
 }
 
 func (fc *FunctionCall) typeinfer(env TypeEnv) (Type, error) {
-	argsTy, err := typesinfer(env, fc.arguments)
+	fcType, err := fc.name.typeinfer(env)
 	if err != nil {
 		return nil, err
 	}
 
-	fcType, err := fc.name.typeinfer(env)
-	if err != nil {
-		return nil, err
+	ty, ok := fcType.(*FunctionType)
+	if !ok {
+		return nil, exprErrorf(fc.name, "type inference failed: %v is not a function", fc.name.ident)
 	}
 
-	ty := fcType.(*FunctionType)
-	if equals(argsTy, ty.paramTypes) {
-		return ty.returnType, nil
+	// The last parameters can be omitted when they have default values.
+	minArgs := len(ty.paramTypes) - len(ty.defaultValues)
+	if len(fc.arguments) < minArgs || len(fc.arguments) > len(ty.paramTypes) {
+		return nil, exprErrorf(fc, "type inference failed: mismatch in arg types on %v", fc.name.ident)
 	}
 
-	return nil, fmt.Errorf("type inference failed: mismatch in arg types on %v", fc.name.ident)
+	// The type variables of generic built-ins are bound by the arguments, from left to right.
+	// This way, a lambda argument can take the types of its parameters from the previous arguments.
+	subst := make(map[string]Type)
+	for i, arg := range fc.arguments {
+		paramTy := substitute(ty.paramTypes[i], subst)
+
+		var argTy Type
+		if lambda, isLambda := arg.(*Lambda); isLambda {
+			var expectedParamsTy []Type
+			if fnParamTy, isFunction := paramTy.(*FunctionType); isFunction {
+				expectedParamsTy = fnParamTy.paramTypes
+			}
+			argTy, err = lambda.typeinferWithParams(env, expectedParamsTy)
+		} else {
+			argTy, err = arg.typeinfer(env)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !matchType(paramTy, argTy, subst) {
+			return nil, exprErrorf(fc, "type inference failed: mismatch in arg types on %v", fc.name.ident)
+		}
+	}
+
+	return defaultTypeVariables(substitute(ty.returnType, subst)), nil
 }
 
 func (l *Lambda) typeinfer(env TypeEnv) (Type, error) {
-	paramsTy, err := typesinfer(env, l.parameters)
-	if err != nil {
-		return nil, err
+	return l.typeinferWithParams(env, nil)
+}
+
+// typeinferWithParams infers the type of the lambda knowing the types expected for its parameters.
+// A parameter without a type annotation takes the expected type, e.g. $r in $any($reviewers(), ($r => $r == "john")).
+func (l *Lambda) typeinferWithParams(env TypeEnv, expectedParamsTy []Type) (Type, error) {
+	paramsTy := make([]Type, len(l.parameters))
+	bindings := make(map[string]Type)
+	for i, param := range l.parameters {
+		if typedParam, isTyped := param.(*TypedExpr); isTyped {
+			variable, ok := typedParam.expr.(*Variable)
+			if !ok {
+				return nil, exprErrorf(typedParam, "typed expression %v is not a variable", typedParam.expr)
+			}
+
+			paramsTy[i] = typedParam.typeOf
+			bindings[variable.ident] = typedParam.typeOf
+			continue
+		}
+
+		variable, isUntyped := param.(*Variable)
+		if isUntyped && i < len(expectedParamsTy) {
+			// The expected type is not known when it comes from empty arrays, e.g. $r in $all([], ($r => $r == 1)).
+			if hasTypeVariables(expectedParamsTy[i]) {
+				return nil, exprErrorf(variable, "type inference failed: cannot infer type of parameter %v", variable.ident)
+			}
+
+			paramsTy[i] = expectedParamsTy[i]
+			bindings[variable.ident] = expectedParamsTy[i]
+			continue
+		}
+
+		paramTy, err := param.typeinfer(env)
+		if err != nil {
+			return nil, err
+		}
+
+		paramsTy[i] = paramTy
 	}
 
-	bodyType, err := l.body.typeinfer(env)
+	bodyType, err := typeinferWithBindings(env, bindings, l.body)
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +185,7 @@ func (l *Lambda) typeinfer(env TypeEnv) (Type, error) {
 
 func (te *TypedExpr) typeinfer(env TypeEnv) (Type, error) {
 	if te.expr.Kind() != VARIABLE_CONST {
-		return nil, fmt.Errorf("typed expression %v is not a variable", te.expr)
+		return nil, exprErrorf(te, "typed expression %v is not a variable", te.expr)
 	}
 
 	varIdent := te.expr.(*Variable).ident
@@ -117,7 +199,7 @@ func (v *Variable) typeinfer(env TypeEnv) (Type, error) {
 	varName := v.ident
 	varType, ok := env[varName]
 	if !ok {
-		return nil, fmt.Errorf("no type for built-in %v. Please check if the mode in the reviewpad.yml file supports it", varName)
+		return nil, exprErrorf(v, "no type for built-in %v. Please check if the mode in the reviewpad.yml file supports it", varName)
 	}
 
 	return varType, nil
@@ -131,6 +213,14 @@ func (i *IntConst) typeinfer(env TypeEnv) (Type, error) {
 	return BuildIntType(), nil
 }
 
+func (t *TimeConst) typeinfer(env TypeEnv) (Type, error) {
+	return BuildTimeType(), nil
+}
+
+func (d *DurationConst) typeinfer(env TypeEnv) (Type, error) {
+	return BuildDurationType(), nil
+}
+
 func (b *BoolConst) typeinfer(env TypeEnv) (Type, error) {
 	return BuildBoolType(), nil
 }
@@ -143,3 +233,202 @@ func (a *Array) typeinfer(env TypeEnv) (Type, error) {
 
 	return BuildArrayType(elemsTy), nil
 }
+
+func (l *Let) typeinfer(env TypeEnv) (Type, error) {
+	valueType, err := l.value.typeinfer(env)
+	if err != nil {
+		return nil, err
+	}
+
+	return typeinferWithBindings(env, map[string]Type{l.variable.ident: valueType}, l.body)
+}
+
+func (c *Conditional) typeinfer(env TypeEnv) (Type, error) {
+	conditionType, err := c.condition.typeinfer(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if !conditionType.equals(BuildBoolType()) {
+		return nil, exprErrorf(c, "type inference failed: condition is not a boolean")
+	}
+
+	thenType, err := c.thenBranch.typeinfer(env)
+	if err != nil {
+		return nil, err
+	}
+
+	elseType, err := c.elseBranch.typeinfer(env)
+	if err != nil {
+		return nil, err
+	}
+
+	ty, ok := unify(thenType, elseType)
+	if !ok {
+		return nil, exprErrorf(c, "type inference failed: mismatch in branch types")
+	}
+
+	return ty, nil
+}
+
+func (fa *FieldAccess) typeinfer(env TypeEnv) (Type, error) {
+	exprType, err := fa.expr.typeinfer(env)
+	if err != nil {
+		return nil, err
+	}
+
+	recordType, ok := exprType.(*RecordType)
+	if !ok {
+		return nil, exprErrorf(fa, "type inference failed: field access on non-record value")
+	}
+
+	fieldType, ok := recordType.fieldsType[fa.field]
+	if !ok {
+		return nil, exprErrorf(fa, "type inference failed: no field %v in record", fa.field)
+	}
+
+	return fieldType, nil
+}
+
+func (i *Interpolation) typeinfer(env TypeEnv) (Type, error) {
+	for _, part := range i.parts {
+		partType, err := part.typeinfer(env)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isInterpolable(partType) {
+			return nil, exprErrorf(part, "type inference failed: expression cannot be converted to a string")
+		}
+	}
+
+	return BuildStringType(), nil
+}
+
+// typeinferWithBindings infers the type of expr with the bindings added to the type environment.
+// Any type shadowed by a binding is restored once the inference is done.
+func typeinferWithBindings(env TypeEnv, bindings map[string]Type, expr Expr) (Type, error) {
+	shadowedTypes := make(map[string]Type)
+
+	for ident, ty := range bindings {
+		if shadowedType, ok := env[ident]; ok {
+			shadowedTypes[ident] = shadowedType
+		}
+
+		env[ident] = ty
+	}
+
+	defer func() {
+		for ident := range bindings {
+			if shadowedType, ok := shadowedTypes[ident]; ok {
+				env[ident] = shadowedType
+			} else {
+				delete(env, ident)
+			}
+		}
+	}()
+
+	return expr.typeinfer(env)
+}
+
+// isElementType checks if a value of type elemType can be an element of an array of type arrayType.
+func isElementType(elemType Type, arrayType Type) bool {
+	elemsTy, isArray := arrayElemsTypes(arrayType)
+	if !isArray {
+		return false
+	}
+
+	for _, ty := range elemsTy {
+		if _, ok := unify(elemType, ty); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkRegex checks that the expression is a valid regex when it is a string literal.
+// Other expressions are only known when they are evaluated.
+func checkRegex(expr Expr) error {
+	pattern, ok := expr.(*StringConst)
+	if !ok {
+		return nil
+	}
+
+	if _, err := regexp.Compile(pattern.value); err != nil {
+		return fmt.Errorf("invalid regex %q: %v", pattern.value, err)
+	}
+
+	return nil
+}
+
+// isOrdered checks if the values of the type can be compared with <, <=, > and >=.
+func isOrdered(ty Type) bool {
+	switch ty.Kind() {
+	case INT_TYPE, TIME_TYPE, DURATION_TYPE:
+		return true
+	}
+
+	return false
+}
+
+// arithmeticType is the type of the result of an arithmetic operation over operands of the given types, if any.
+// Besides integers, it supports strings concatenation and the arithmetic of times and durations, e.g. $createdAt() + 2 days.
+func arithmeticType(op string, lhsType Type, rhsType Type) (Type, bool) {
+	lhsKind := lhsType.Kind()
+	rhsKind := rhsType.Kind()
+
+	if lhsKind == INT_TYPE && rhsKind == INT_TYPE {
+		return BuildIntType(), true
+	}
+
+	switch op {
+	case ADD_OP:
+		switch {
+		case lhsKind == STRING_TYPE && rhsKind == STRING_TYPE:
+			return BuildStringType(), true
+		case lhsKind == TIME_TYPE && rhsKind == DURATION_TYPE, lhsKind == DURATION_TYPE && rhsKind == TIME_TYPE:
+			return BuildTimeType(), true
+		case lhsKind == DURATION_TYPE && rhsKind == DURATION_TYPE:
+			return BuildDurationType(), true
+		}
+	case SUB_OP:
+		switch {
+		case lhsKind == TIME_TYPE && rhsKind == TIME_TYPE:
+			return BuildDurationType(), true
+		case lhsKind == TIME_TYPE && rhsKind == DURATION_TYPE:
+			return BuildTimeType(), true
+		case lhsKind == DURATION_TYPE && rhsKind == DURATION_TYPE:
+			return BuildDurationType(), true
+		}
+	case MUL_OP:
+		if lhsKind == DURATION_TYPE && rhsKind == INT_TYPE || lhsKind == INT_TYPE && rhsKind == DURATION_TYPE {
+			return BuildDurationType(), true
+		}
+	case DIV_OP:
+		if lhsKind == DURATION_TYPE && rhsKind == INT_TYPE {
+			return BuildDurationType(), true
+		}
+	}
+
+	return nil, false
+}
+
+// isInterpolable checks if a value of the type can be converted to a string.
+func isInterpolable(ty Type) bool {
+	switch ty.Kind() {
+	case STRING_TYPE, INT_TYPE, BOOL_TYPE, TIME_TYPE, DURATION_TYPE:
+		return true
+	case ARRAY_TYPE:
+		for _, elemType := range ty.(*ArrayType).elemsType {
+			if !isInterpolable(elemType) {
+				return false
+			}
+		}
+		return true
+	case ARRAY_OF_TYPE:
+		return isInterpolable(ty.(*ArrayOfType).elemType)
+	}
+
+	return false
+}
@@ -230,6 +230,101 @@ func TestTypeInfer_WhenBinaryOpHasOrOperator(t *testing.T) {
 	assert.Equal(t, wantType, gotType)
 }
 
+func TestTypeInfer_WhenBinaryOpHasAddOperatorOnInts(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	binaryOp := BuildBinaryOp(BuildIntConst(1), addOperator(), BuildIntConst(1))
+	gotType, err := binaryOp.typeinfer(mockedTypeEnv)
+
+	wantType := BuildIntType()
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantType, gotType)
+}
+
+func TestTypeInfer_WhenBinaryOpHasAddOperatorOnStrings(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	binaryOp := BuildBinaryOp(BuildStringConst("a"), addOperator(), BuildStringConst("b"))
+	gotType, err := binaryOp.typeinfer(mockedTypeEnv)
+
+	wantType := BuildStringType()
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantType, gotType)
+}
+
+func TestTypeInfer_WhenBinaryOpHasAddOperatorOnMixedTypes(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	binaryOp := BuildBinaryOp(BuildStringConst("a"), addOperator(), BuildIntConst(1))
+	gotType, err := binaryOp.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "type inference failed")
+}
+
+func TestTypeInfer_WhenBinaryOpHasMatchOperator(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	binaryOp := BuildMatchOp(BuildStringConst("feat: add"), BuildStringConst("^(feat|fix):"))
+	gotType, err := binaryOp.typeinfer(mockedTypeEnv)
+
+	wantType := BuildBoolType()
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantType, gotType)
+}
+
+func TestTypeInfer_WhenBinaryOpHasMatchOperatorWithInvalidRegex(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	binaryOp := BuildMatchOp(BuildStringConst("feat: add"), BuildStringConst("^(feat"))
+	gotType, err := binaryOp.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "type inference failed: invalid regex \"^(feat\": error parsing regexp: missing closing ): `^(feat`")
+}
+
+func TestTypeInfer_WhenBinaryOpHasMatchOperatorOnInts(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	binaryOp := BuildMatchOp(BuildIntConst(1), BuildStringConst("1"))
+	gotType, err := binaryOp.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "type inference failed")
+}
+
+func TestTypeInfer_WhenBinaryOpHasArithmeticOperator(t *testing.T) {
+	tests := map[string]BinaryOperator{
+		"sub": subOperator(),
+		"mul": mulOperator(),
+		"div": divOperator(),
+		"mod": modOperator(),
+	}
+
+	for name, op := range tests {
+		t.Run(name, func(t *testing.T) {
+			binaryOp := BuildBinaryOp(BuildIntConst(4), op, BuildIntConst(2))
+			gotType, err := binaryOp.typeinfer(MockTypeEnv())
+
+			assert.Nil(t, err)
+			assert.Equal(t, BuildIntType(), gotType)
+		})
+	}
+}
+
+func TestTypeInfer_WhenBinaryOpHasArithmeticOperatorOnStrings(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	binaryOp := BuildBinaryOp(BuildStringConst("a"), subOperator(), BuildStringConst("b"))
+	gotType, err := binaryOp.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "type inference failed")
+}
+
 func TestTypeInfer_WhenBinaryOpOperatorIsNotAValidOp(t *testing.T) {
 	mockedTypeEnv := MockTypeEnv()
 
@@ -285,6 +380,56 @@ func TestTypeInfer_WhenFunctionCallHasMismatchInArgTypes(t *testing.T) {
 	assert.EqualError(t, err, "type inference failed: mismatch in arg types on returnStr")
 }
 
+func TestTypeInfer_WhenFunctionCallHasOptionalParams(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+	mockedTypeEnv["repeat"] = BuildFunctionTypeWithDefaults(
+		[]Type{BuildStringType(), BuildIntType(), BuildStringType()},
+		BuildStringType(),
+		[]Value{BuildIntValue(2), BuildStringValue("")},
+	)
+
+	tests := map[string]struct {
+		input  string
+		wantTy Type
+	}{
+		"all args":                {input: `$repeat("a", 3, ",")`, wantTy: BuildStringType()},
+		"last arg omitted":        {input: `$repeat("a", 3)`, wantTy: BuildStringType()},
+		"optional args omitted":   {input: `$repeat("a")`, wantTy: BuildStringType()},
+		"required arg omitted":    {input: `$repeat()`},
+		"too many args":           {input: `$repeat("a", 3, ",", 1)`},
+		"optional arg wrong type": {input: `$repeat("a", "3")`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotTy, err := expr.typeinfer(mockedTypeEnv)
+
+			if test.wantTy == nil {
+				assert.EqualError(t, err, "type inference failed: mismatch in arg types on repeat")
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.wantTy, gotTy)
+		})
+	}
+}
+
+func TestTypeInfer_WhenFunctionCallNameIsNotAFunction(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+	mockedTypeEnv["notAFunction"] = BuildIntType()
+
+	fc := BuildFunctionCall(BuildVariable("notAFunction"), []Expr{})
+	gotType, err := fc.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "type inference failed: notAFunction is not a function")
+}
+
 func TestTypeInfer_WhenLambdaParamTypeHasError(t *testing.T) {
 	mockedTypeEnv := MockTypeEnv()
 
@@ -449,3 +594,335 @@ func TestTypeInfer_WhenArrayElemsTypeHasCorrectTypes(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, wantType, gotType)
 }
+
+func TestTypeInfer_WhenLetValueHasTypeError(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	let := BuildLet(BuildVariable("x"), BuildVariable("nonBuiltIn"), BuildVariable("x"))
+	gotType, err := let.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "no type for built-in nonBuiltIn. Please check if the mode in the reviewpad.yml file supports it")
+}
+
+func TestTypeInfer_WhenLetBodyHasTypeError(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	let := BuildLet(BuildVariable("x"), BuildIntConst(1), BuildVariable("nonBuiltIn"))
+	gotType, err := let.typeinfer(mockedTypeEnv)
+
+	_, isBound := mockedTypeEnv["x"]
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "no type for built-in nonBuiltIn. Please check if the mode in the reviewpad.yml file supports it")
+	assert.False(t, isBound)
+}
+
+func TestTypeInfer_WhenLetHasCorrectTypes(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	// returnStr is a mocked built-in that receives and returns a string value
+	let := BuildLet(
+		BuildVariable("x"),
+		BuildFunctionCall(BuildVariable("returnStr"), []Expr{BuildStringConst("hello")}),
+		BuildBinaryOp(BuildVariable("x"), eqOperator(), BuildStringConst("hello")),
+	)
+	gotType, err := let.typeinfer(mockedTypeEnv)
+
+	_, isBound := mockedTypeEnv["x"]
+
+	wantType := BuildBoolType()
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantType, gotType)
+	assert.False(t, isBound)
+}
+
+func TestTypeInfer_WhenLetShadowsBuiltIn(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	// zeroConst is a mocked built-in that is a constant function of type int
+	let := BuildLet(BuildVariable("zeroConst"), BuildStringConst("hello"), BuildVariable("zeroConst"))
+	gotType, err := let.typeinfer(mockedTypeEnv)
+
+	wantType := BuildStringType()
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantType, gotType)
+	assert.Equal(t, BuildFunctionType([]Type{}, BuildIntType()), mockedTypeEnv["zeroConst"])
+}
+
+func TestTypeInfer_WhenConditionalConditionIsNotBool(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	conditional := BuildConditional(BuildIntConst(1), BuildIntConst(1), BuildIntConst(2))
+	gotType, err := conditional.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "type inference failed: condition is not a boolean")
+}
+
+func TestTypeInfer_WhenConditionalBranchesHaveDiffTypes(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	conditional := BuildConditional(BuildBoolConst(true), BuildIntConst(1), BuildStringConst("hello"))
+	gotType, err := conditional.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "type inference failed: mismatch in branch types")
+}
+
+func TestTypeInfer_WhenConditionalBranchHasTypeError(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	conditional := BuildConditional(BuildBoolConst(true), BuildIntConst(1), BuildVariable("nonBuiltIn"))
+	gotType, err := conditional.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "no type for built-in nonBuiltIn. Please check if the mode in the reviewpad.yml file supports it")
+}
+
+func TestTypeInfer_WhenConditionalBranchesUnify(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	conditional := BuildConditional(
+		BuildBoolConst(true),
+		BuildArray([]Expr{BuildStringConst("john")}),
+		BuildArray([]Expr{BuildStringConst("jane"), BuildStringConst("mary")}),
+	)
+	gotType, err := conditional.typeinfer(mockedTypeEnv)
+
+	wantType := BuildArrayOfType(BuildStringType())
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantType, gotType)
+}
+
+func TestTypeInfer_WhenFieldAccessOnRecord(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+	mockedTypeEnv["review"] = BuildRecordType(map[string]Type{
+		"user":        BuildStringType(),
+		"submittedAt": BuildIntType(),
+	})
+
+	fieldAccess := BuildFieldAccess(BuildVariable("review"), "submittedAt")
+	gotType, err := fieldAccess.typeinfer(mockedTypeEnv)
+
+	wantType := BuildIntType()
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantType, gotType)
+}
+
+func TestTypeInfer_WhenFieldAccessOnUnknownField(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+	mockedTypeEnv["review"] = BuildRecordType(map[string]Type{"user": BuildStringType()})
+
+	fieldAccess := BuildFieldAccess(BuildVariable("review"), "state")
+	gotType, err := fieldAccess.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "type inference failed: no field state in record")
+}
+
+func TestTypeInfer_WhenFieldAccessOnNonRecord(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	fieldAccess := BuildFieldAccess(BuildStringConst("review"), "user")
+	gotType, err := fieldAccess.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "type inference failed: field access on non-record value")
+}
+
+func TestTypeInfer_WhenInterpolation(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	interpolation := BuildInterpolation([]Expr{
+		BuildStringConst("zero is "),
+		BuildFunctionCall(BuildVariable("zeroConst"), []Expr{}),
+		BuildStringConst(" in "),
+		BuildArray([]Expr{BuildBoolConst(true), BuildIntConst(0)}),
+	})
+	gotType, err := interpolation.typeinfer(mockedTypeEnv)
+
+	wantType := BuildStringType()
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantType, gotType)
+}
+
+func TestTypeInfer_WhenInterpolationOfRecord(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+	mockedTypeEnv["review"] = BuildRecordType(map[string]Type{"user": BuildStringType()})
+
+	interpolation := BuildInterpolation([]Expr{BuildStringConst("review "), BuildVariable("review")})
+	gotType, err := interpolation.typeinfer(mockedTypeEnv)
+
+	assert.Nil(t, gotType)
+	assert.EqualError(t, err, "type inference failed: expression cannot be converted to a string")
+}
+
+func TestTypeInfer_WhenTimeArithmetic(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+	mockedTypeEnv["createdAt"] = BuildFunctionType([]Type{}, BuildTimeType())
+
+	tests := map[string]struct {
+		input    string
+		wantType Type
+	}{
+		"time minus time":           {input: `$createdAt() - 2022-04-05`, wantType: BuildDurationType()},
+		"time plus duration":        {input: `$createdAt() + 2 days`, wantType: BuildTimeType()},
+		"duration plus time":        {input: `2 days + $createdAt()`, wantType: BuildTimeType()},
+		"time minus duration":       {input: `$createdAt() - 1 hour`, wantType: BuildTimeType()},
+		"duration plus duration":    {input: `1 day + 2 hours`, wantType: BuildDurationType()},
+		"duration times int":        {input: `2 days * 3`, wantType: BuildDurationType()},
+		"int times duration":        {input: `3 * 2 days`, wantType: BuildDurationType()},
+		"duration divided by int":   {input: `2 days / 2`, wantType: BuildDurationType()},
+		"comparison of times":       {input: `$createdAt() < 3 days ago`, wantType: BuildBoolType()},
+		"comparison of durations":   {input: `$createdAt() - 2022-04-05 > 2 days`, wantType: BuildBoolType()},
+		"time typed expression":     {input: `$t: Time`, wantType: BuildTimeType()},
+		"duration typed expression": {input: `$d: Duration`, wantType: BuildDurationType()},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotType, err := expr.typeinfer(mockedTypeEnv)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantType, gotType)
+		})
+	}
+}
+
+func TestTypeInfer_WhenTimeArithmeticFails(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	tests := map[string]string{
+		"time plus time":           `2022-04-05 + 2022-04-06`,
+		"duration minus time":      `2 days - 2022-04-05`,
+		"time times int":           `2022-04-05 * 2`,
+		"int divided by duration":  `2 / 2 days`,
+		"duration modulo int":      `2 days % 2`,
+		"time compared to int":     `2022-04-05 > 10`,
+		"duration compared to int": `2 days > 10`,
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Parse(input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotType, err := expr.typeinfer(mockedTypeEnv)
+
+			assert.Nil(t, gotType)
+			assert.EqualError(t, err, "type inference failed")
+		})
+	}
+}
+
+func TestTypeInfer_WhenGenericFunctionCall(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+	mockedTypeEnv["map"] = BuildFunctionType(
+		[]Type{
+			BuildArrayOfType(BuildTypeVariable("A")),
+			BuildFunctionType([]Type{BuildTypeVariable("A")}, BuildTypeVariable("B")),
+		},
+		BuildArrayOfType(BuildTypeVariable("B")),
+	)
+
+	tests := map[string]struct {
+		input   string
+		wantTy  Type
+		wantErr string
+	}{
+		"lambda with inferred parameter": {
+			input:  `$map(["a", "b"], ($x => $x == "a"))`,
+			wantTy: BuildArrayOfType(BuildBoolType()),
+		},
+		"lambda with typed parameter": {
+			input:  `$map([1, 2], ($x: Int => $x + 1))`,
+			wantTy: BuildArrayOfType(BuildIntType()),
+		},
+		"lambda with mismatch in parameter type": {
+			input:   `$map([1, 2], ($x: String => $x))`,
+			wantErr: "type inference failed: mismatch in arg types on map",
+		},
+		"lambda with body type error": {
+			input:   `$map(["a"], ($x => $x + 1))`,
+			wantErr: "type inference failed",
+		},
+		"lambda with parameter that cannot be inferred": {
+			input:   `$map([], ($x => $x))`,
+			wantErr: "type inference failed: cannot infer type of parameter x",
+		},
+		"lambda with typed parameter over empty array": {
+			input:  `$map([], ($x: Int => $x + 1))`,
+			wantTy: BuildArrayOfType(BuildIntType()),
+		},
+		"non array argument": {
+			input:   `$map("a", ($x => $x))`,
+			wantErr: "type inference failed: mismatch in arg types on map",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotTy, err := expr.typeinfer(mockedTypeEnv)
+
+			if test.wantErr != "" {
+				assert.EqualError(t, err, test.wantErr)
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.wantTy, gotTy)
+			assert.NotContains(t, mockedTypeEnv, "x")
+		})
+	}
+}
+
+func TestTypeInfer_WhenInOp(t *testing.T) {
+	mockedTypeEnv := MockTypeEnv()
+
+	tests := map[string]struct {
+		input  string
+		wantTy Type
+	}{
+		"element in array":        {input: `"john" in ["john", "jane"]`, wantTy: BuildBoolType()},
+		"element not in array":    {input: `1 not in [2, 3]`, wantTy: BuildBoolType()},
+		"element in empty array":  {input: `1 in []`, wantTy: BuildBoolType()},
+		"array in array":          {input: `[1] in [[1], [1, 2]]`, wantTy: BuildBoolType()},
+		"different element type":  {input: `1 in ["1"]`},
+		"right operand not array": {input: `"john" in "john"`},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Parse(test.input)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			gotTy, err := expr.typeinfer(mockedTypeEnv)
+
+			if test.wantTy == nil {
+				assert.EqualError(t, err, "type inference failed")
+			} else {
+				assert.Nil(t, err)
+			}
+			assert.Equal(t, test.wantTy, gotTy)
+		})
+	}
+}
@@ -4,6 +4,12 @@
 
 package aladino
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
 type Value interface {
 	HasKindOf(string) bool
 	Kind() string
@@ -15,8 +21,10 @@ const (
 	BOOL_VALUE     string = "BoolValue"
 	STRING_VALUE   string = "StringValue"
 	TIME_VALUE     string = "TimeValue"
+	DURATION_VALUE string = "DurationValue"
 	ARRAY_VALUE    string = "ArrayValue"
 	FUNCTION_VALUE string = "FunctionValue"
+	RECORD_VALUE   string = "RecordValue"
 )
 
 // IntValue represents an integer value
@@ -99,6 +107,7 @@ func (sVal *StringValue) HasKindOf(ty string) bool {
 	return sVal.Kind() == ty
 }
 
+// TimeValue represents an instant in time as the number of seconds since the Unix epoch
 type TimeValue struct {
 	Val int
 }
@@ -125,6 +134,82 @@ func (thisVal *TimeValue) Equals(other Value) bool {
 	return thisVal.Val == other.(*TimeValue).Val
 }
 
+// Time returns the instant of the time value in UTC
+func (tVal *TimeValue) Time() time.Time {
+	return time.Unix(int64(tVal.Val), 0).UTC()
+}
+
+// String formats the time value according to RFC3339, e.g. 2022-04-05T22:01:50Z
+func (tVal *TimeValue) String() string {
+	return tVal.Time().Format(time.RFC3339)
+}
+
+// DurationValue represents an amount of time as a number of seconds
+type DurationValue struct {
+	Val int
+}
+
+func BuildDurationValue(dVal int) *DurationValue {
+	return &DurationValue{
+		Val: dVal,
+	}
+}
+
+func (dVal *DurationValue) Kind() string {
+	return DURATION_VALUE
+}
+
+func (dVal *DurationValue) HasKindOf(kind string) bool {
+	return dVal.Kind() == kind
+}
+
+func (thisVal *DurationValue) Equals(other Value) bool {
+	if thisVal.Kind() != other.Kind() {
+		return false
+	}
+
+	return thisVal.Val == other.(*DurationValue).Val
+}
+
+var durationUnits = []struct {
+	name    string
+	seconds int
+}{
+	{"day", 24 * 60 * 60},
+	{"hour", 60 * 60},
+	{"minute", 60},
+	{"second", 1},
+}
+
+// String formats the duration value in days, hours, minutes and seconds, e.g. 2 days 1 hour 30 minutes
+func (dVal *DurationValue) String() string {
+	seconds := dVal.Val
+	if seconds == 0 {
+		return "0 seconds"
+	}
+
+	sign := ""
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	parts := make([]string, 0)
+	for _, unit := range durationUnits {
+		amount := seconds / unit.seconds
+		seconds %= unit.seconds
+
+		switch {
+		case amount == 1:
+			parts = append(parts, fmt.Sprintf("1 %v", unit.name))
+		case amount > 1:
+			parts = append(parts, fmt.Sprintf("%v %vs", amount, unit.name))
+		}
+	}
+
+	return sign + strings.Join(parts, " ")
+}
+
 // ArrayValue represents an array value
 type ArrayValue struct {
 	// defaultValue
@@ -166,11 +251,11 @@ func (aVal *ArrayValue) HasKindOf(ty string) bool {
 
 // FunctionValue represents a function value
 type FunctionValue struct {
-	// defaultValue
-	Fn func(args []Value) Value
+	// Fn fails when the body of the function fails, e.g. on a division by zero.
+	Fn func(args []Value) (Value, error)
 }
 
-func BuildFunctionValue(fn func(args []Value) Value) *FunctionValue {
+func BuildFunctionValue(fn func(args []Value) (Value, error)) *FunctionValue {
 	return &FunctionValue{fn}
 }
 
@@ -186,3 +271,41 @@ func (thisVal *FunctionValue) Equals(other Value) bool {
 func (fVal *FunctionValue) HasKindOf(ty string) bool {
 	return fVal.Kind() == ty
 }
+
+// RecordValue represents a record value, i.e. a set of named fields
+type RecordValue struct {
+	Vals map[string]Value
+}
+
+func BuildRecordValue(fieldVals map[string]Value) *RecordValue {
+	return &RecordValue{Vals: fieldVals}
+}
+
+func (rVal *RecordValue) Kind() string {
+	return RECORD_VALUE
+}
+
+func (thisVal *RecordValue) Equals(other Value) bool {
+	if thisVal.Kind() != other.Kind() {
+		return false
+	}
+
+	otherRecord := other.(*RecordValue)
+
+	if len(thisVal.Vals) != len(otherRecord.Vals) {
+		return false
+	}
+
+	for field, val := range thisVal.Vals {
+		otherVal, ok := otherRecord.Vals[field]
+		if !ok || !val.Equals(otherVal) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (rVal *RecordValue) HasKindOf(ty string) bool {
+	return rVal.Kind() == ty
+}
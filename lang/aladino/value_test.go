@@ -6,6 +6,7 @@ package aladino_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/reviewpad/reviewpad/v3/lang/aladino"
 	"github.com/stretchr/testify/assert"
@@ -73,8 +74,8 @@ func TestBuildArrayValue(t *testing.T) {
 }
 
 func TestBuildFunctionValue(t *testing.T) {
-	fn := func(args []aladino.Value) aladino.Value {
-		return &aladino.IntValue{Val: 0}
+	fn := func(args []aladino.Value) (aladino.Value, error) {
+		return &aladino.IntValue{Val: 0}, nil
 	}
 
 	wantVal := &aladino.FunctionValue{fn}
@@ -135,8 +136,8 @@ func TestFunctionValueKind(t *testing.T) {
 	wantVal := aladino.FUNCTION_VALUE
 
 	fnVal := &aladino.FunctionValue{
-		func(args []aladino.Value) aladino.Value {
-			return &aladino.IntValue{Val: 0}
+		func(args []aladino.Value) (aladino.Value, error) {
+			return &aladino.IntValue{Val: 0}, nil
 		},
 	}
 	gotVal := fnVal.Kind()
@@ -178,8 +179,8 @@ func TestArrayValueHasKindOf(t *testing.T) {
 
 func TestFunctionValueHasKindOf(t *testing.T) {
 	fnVal := &aladino.FunctionValue{
-		func(args []aladino.Value) aladino.Value {
-			return &aladino.IntValue{Val: 0}
+		func(args []aladino.Value) (aladino.Value, error) {
+			return &aladino.IntValue{Val: 0}, nil
 		},
 	}
 
@@ -309,14 +310,14 @@ func TestArrayValueEquals_WhenFalse(t *testing.T) {
 
 func TestFunctionValueEquals_WhenTrue(t *testing.T) {
 	fnVal := &aladino.FunctionValue{
-		func(args []aladino.Value) aladino.Value {
-			return &aladino.IntValue{Val: 0}
+		func(args []aladino.Value) (aladino.Value, error) {
+			return &aladino.IntValue{Val: 0}, nil
 		},
 	}
 
 	otherVal := &aladino.FunctionValue{
-		func(args []aladino.Value) aladino.Value {
-			return &aladino.IntValue{Val: 0}
+		func(args []aladino.Value) (aladino.Value, error) {
+			return &aladino.IntValue{Val: 0}, nil
 		},
 	}
 
@@ -325,8 +326,8 @@ func TestFunctionValueEquals_WhenTrue(t *testing.T) {
 
 func TestFunctionValueEquals_WhenFalse(t *testing.T) {
 	fnVal := &aladino.FunctionValue{
-		func(args []aladino.Value) aladino.Value {
-			return &aladino.IntValue{Val: 0}
+		func(args []aladino.Value) (aladino.Value, error) {
+			return &aladino.IntValue{Val: 0}, nil
 		},
 	}
 
@@ -334,3 +335,116 @@ func TestFunctionValueEquals_WhenFalse(t *testing.T) {
 
 	assert.False(t, fnVal.Equals(otherVal))
 }
+
+func TestBuildRecordValue(t *testing.T) {
+	fieldVals := map[string]aladino.Value{"user": aladino.BuildStringValue("john")}
+	wantVal := &aladino.RecordValue{Vals: fieldVals}
+
+	gotVal := aladino.BuildRecordValue(fieldVals)
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestRecordValueKind(t *testing.T) {
+	wantVal := aladino.RECORD_VALUE
+
+	recordVal := &aladino.RecordValue{Vals: map[string]aladino.Value{}}
+	gotVal := recordVal.Kind()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestRecordValueHasKindOf(t *testing.T) {
+	recordVal := &aladino.RecordValue{Vals: map[string]aladino.Value{}}
+
+	assert.True(t, recordVal.HasKindOf(aladino.RECORD_VALUE))
+}
+
+func TestRecordValueEquals_WhenDiffKinds(t *testing.T) {
+	recordVal := &aladino.RecordValue{Vals: map[string]aladino.Value{}}
+	otherVal := &aladino.IntValue{Val: 0}
+
+	assert.False(t, recordVal.Equals(otherVal))
+}
+
+func TestRecordValueEquals_WhenDiffFields(t *testing.T) {
+	recordVal := &aladino.RecordValue{Vals: map[string]aladino.Value{"user": &aladino.StringValue{Val: "john"}}}
+	otherVal := &aladino.RecordValue{Vals: map[string]aladino.Value{"author": &aladino.StringValue{Val: "john"}}}
+
+	assert.False(t, recordVal.Equals(otherVal))
+}
+
+func TestRecordValueEquals_WhenTrue(t *testing.T) {
+	recordVal := &aladino.RecordValue{Vals: map[string]aladino.Value{"user": &aladino.StringValue{Val: "john"}}}
+	otherVal := &aladino.RecordValue{Vals: map[string]aladino.Value{"user": &aladino.StringValue{Val: "john"}}}
+
+	assert.True(t, recordVal.Equals(otherVal))
+}
+
+func TestRecordValueEquals_WhenFalse(t *testing.T) {
+	recordVal := &aladino.RecordValue{Vals: map[string]aladino.Value{"user": &aladino.StringValue{Val: "john"}}}
+	otherVal := &aladino.RecordValue{Vals: map[string]aladino.Value{"user": &aladino.StringValue{Val: "jane"}}}
+
+	assert.False(t, recordVal.Equals(otherVal))
+}
+
+func TestTimeValueString(t *testing.T) {
+	timeVal := aladino.BuildTimeValue(int(time.Date(2022, 4, 5, 22, 1, 50, 0, time.UTC).Unix()))
+
+	assert.Equal(t, "2022-04-05T22:01:50Z", timeVal.String())
+}
+
+func TestBuildDurationValue(t *testing.T) {
+	wantVal := &aladino.DurationValue{Val: 60}
+
+	gotVal := aladino.BuildDurationValue(60)
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestDurationValueKind(t *testing.T) {
+	wantVal := aladino.DURATION_VALUE
+
+	durationVal := &aladino.DurationValue{Val: 60}
+	gotVal := durationVal.Kind()
+
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestDurationValueHasKindOf(t *testing.T) {
+	durationVal := &aladino.DurationValue{Val: 60}
+
+	assert.True(t, durationVal.HasKindOf(aladino.DURATION_VALUE))
+}
+
+func TestDurationValueEquals_WhenDiffKinds(t *testing.T) {
+	durationVal := &aladino.DurationValue{Val: 60}
+	otherVal := &aladino.TimeValue{Val: 60}
+
+	assert.False(t, durationVal.Equals(otherVal))
+}
+
+func TestDurationValueEquals_WhenTrue(t *testing.T) {
+	durationVal := &aladino.DurationValue{Val: 60}
+	otherVal := &aladino.DurationValue{Val: 60}
+
+	assert.True(t, durationVal.Equals(otherVal))
+}
+
+func TestDurationValueString(t *testing.T) {
+	tests := map[string]struct {
+		seconds    int
+		wantString string
+	}{
+		"zero":     {seconds: 0, wantString: "0 seconds"},
+		"singular": {seconds: 24*60*60 + 60*60 + 60 + 1, wantString: "1 day 1 hour 1 minute 1 second"},
+		"plural":   {seconds: 3 * 24 * 60 * 60, wantString: "3 days"},
+		"negative": {seconds: -90 * 60, wantString: "-1 hour 30 minutes"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.wantString, aladino.BuildDurationValue(test.seconds).String())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+// walk visits the expression and its subexpressions, parents before children.
+func walk(expr Expr, visit func(Expr)) {
+	visit(expr)
+
+	switch e := expr.(type) {
+	case *UnaryOp:
+		walk(e.expr, visit)
+	case *BinaryOp:
+		walk(e.lhs, visit)
+		walk(e.rhs, visit)
+	case *FunctionCall:
+		walk(e.name, visit)
+		walkAll(e.arguments, visit)
+	case *Array:
+		walkAll(e.elems, visit)
+	case *TypedExpr:
+		walk(e.expr, visit)
+	case *Lambda:
+		walkAll(e.parameters, visit)
+		walk(e.body, visit)
+	case *Let:
+		walk(e.variable, visit)
+		walk(e.value, visit)
+		walk(e.body, visit)
+	case *Conditional:
+		walk(e.condition, visit)
+		walk(e.thenBranch, visit)
+		walk(e.elseBranch, visit)
+	case *FieldAccess:
+		walk(e.expr, visit)
+	case *Interpolation:
+		walkAll(e.parts, visit)
+	}
+}
+
+func walkAll(exprs []Expr, visit func(Expr)) {
+	for _, expr := range exprs {
+		walk(expr, visit)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package aladino
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalk(t *testing.T) {
+	expr, err := Parse(`let $a = [$size()]; $a == [1] && $any($a, ($b => "${$b.c}" == "" || !$isDraft()))`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	gotIdents := make([]string, 0)
+	walk(expr, func(e Expr) {
+		if variable, ok := e.(*Variable); ok {
+			gotIdents = append(gotIdents, variable.ident)
+		}
+	})
+
+	assert.Equal(t, []string{"a", "size", "a", "any", "a", "b", "b", "isDraft"}, gotIdents)
+}
@@ -0,0 +1,61 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package lsp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+// builtInPrefixRegex matches the start of the name of a built-in before the cursor, e.g. $rev.
+var builtInPrefixRegex = regexp.MustCompile(`\$([a-zA-Z][a-zA-Z0-9]*)?$`)
+
+// Complete lists the built-ins whose names start with the name typed before the position, along with their types.
+func Complete(builtIns *aladino.BuiltIns, text string, position Position) []CompletionItem {
+	items := make([]CompletionItem, 0)
+
+	match := builtInPrefixRegex.FindStringSubmatch(linePrefix(text, position))
+	if match == nil {
+		return items
+	}
+
+	prefix := match[1]
+
+	for name, function := range builtIns.Functions {
+		if strings.HasPrefix(name, prefix) {
+			items = append(items, CompletionItem{Label: name, Kind: completionItemKindFunction, Detail: aladino.PrintType(function.Type)})
+		}
+	}
+
+	for name, action := range builtIns.Actions {
+		if strings.HasPrefix(name, prefix) {
+			items = append(items, CompletionItem{Label: name, Kind: completionItemKindFunction, Detail: aladino.PrintType(action.Type)})
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Label < items[j].Label
+	})
+
+	return items
+}
+
+// linePrefix returns the text of the line of the position up to the position.
+func linePrefix(text string, position Position) string {
+	lines := strings.Split(text, "\n")
+	if position.Line < 0 || position.Line >= len(lines) {
+		return ""
+	}
+
+	line := []rune(lines[position.Line])
+	if position.Character < 0 || position.Character > len(line) {
+		return string(line)
+	}
+
+	return string(line[:position.Character])
+}
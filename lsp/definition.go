@@ -0,0 +1,83 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package lsp
+
+import (
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mentionRegex matches the mentions of rules and groups in the specs, e.g. $rule("is-small").
+var mentionRegex = regexp.MustCompile(`\$(rule|group)\(\s*"([^"]*)"\s*\)`)
+
+// FindDefinition finds the name of the rule or group mentioned at the position,
+// either in a spec, e.g. $group("owners"), or as a rule of a workflow.
+// It returns nil when there is no mention at the position or its definition is not in the document, e.g. it is imported.
+func FindDefinition(text string, position Position) *Range {
+	file := parseDocument(text)
+	lines := strings.Split(text, "\n")
+
+	offset := len(linePrefix(text, position))
+	if position.Line >= 0 && position.Line < len(lines) {
+		for _, match := range mentionRegex.FindAllStringSubmatchIndex(lines[position.Line], -1) {
+			if offset < match[0] || offset > match[1] {
+				continue
+			}
+
+			// The definitions of $rule("x") and $group("x") are in the rules and groups sections.
+			section := lines[position.Line][match[2]:match[3]] + "s"
+			return definitionRange(lines, file, section, lines[position.Line][match[4]:match[5]])
+		}
+	}
+
+	for _, workflow := range sequenceItems(mappingValue(file, "workflows")) {
+		for _, rule := range sequenceItems(mappingValue(workflow, "if")) {
+			if rule.Kind == yaml.MappingNode {
+				rule = mappingValue(rule, "rule")
+			}
+
+			if rule == nil || rule.Kind != yaml.ScalarNode {
+				continue
+			}
+
+			if isInRange(nodeRange(lines, rule), position) {
+				return definitionRange(lines, file, "rules", rule.Value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// definitionRange is the range of the name of the rule or group with the given name.
+func definitionRange(lines []string, file *yaml.Node, section, name string) *Range {
+	for _, item := range sequenceItems(mappingValue(file, section)) {
+		nameNode := mappingValue(item, "name")
+		if nameNode != nil && nameNode.Value == name {
+			nameRange := nodeRange(lines, nameNode)
+			return &nameRange
+		}
+	}
+
+	return nil
+}
+
+func isInRange(r Range, position Position) bool {
+	if position.Line < r.Start.Line || position.Line > r.End.Line {
+		return false
+	}
+
+	if position.Line == r.Start.Line && position.Character < r.Start.Character {
+		return false
+	}
+
+	if position.Line == r.End.Line && position.Character > r.End.Character {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,87 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package lsp
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gh "github.com/reviewpad/reviewpad/v3/codehost/github"
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+var yamlErrorLineRegex = regexp.MustCompile(`^yaml: line (\d+):`)
+
+// Diagnose loads and lints the reviewpad file at filePath the same way reviewpad does before running it.
+// The errors of the specs are located in the specs, the other errors are reported at the start of the file.
+func Diagnose(builtIns *aladino.BuiltIns, githubClient *gh.GithubClient, filePath string, text string) []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+
+	err := lint(builtIns, githubClient, filePath, text)
+	if err != nil {
+		diagnostics = append(diagnostics, buildDiagnostic(text, err))
+	}
+
+	return diagnostics
+}
+
+func lint(builtIns *aladino.BuiltIns, githubClient *gh.GithubClient, filePath string, text string) error {
+	file, err := engine.LoadFile(context.Background(), githubClient, filePath, []byte(text))
+	if err != nil {
+		return err
+	}
+
+	if err := engine.Lint(file); err != nil {
+		return err
+	}
+
+	return aladino.Lint(file, builtIns)
+}
+
+func buildDiagnostic(text string, err error) Diagnostic {
+	diagnostic := Diagnostic{
+		Severity: diagnosticSeverityError,
+		Source:   "reviewpad",
+		Message:  err.Error(),
+	}
+
+	if match := yamlErrorLineRegex.FindStringSubmatch(err.Error()); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		diagnostic.Range = Range{Start: Position{Line: line - 1}, End: Position{Line: line - 1}}
+		return diagnostic
+	}
+
+	var lintErr *aladino.LintError
+	if !errors.As(err, &lintErr) {
+		return diagnostic
+	}
+
+	// Specs added by the loader, e.g. inline rules, are not in the document.
+	node := resolvePath(parseDocument(text), lintErr.Path)
+	if node == nil {
+		return diagnostic
+	}
+
+	lines := strings.Split(text, "\n")
+
+	var specDiagnostic *aladino.Diagnostic
+	if errors.As(lintErr.Err, &specDiagnostic) {
+		diagnostic.Message = specDiagnostic.Message
+		diagnostic.Range = Range{
+			Start: specPosition(lines, node, specDiagnostic.Span.Start),
+			End:   specPosition(lines, node, specDiagnostic.Span.End),
+		}
+		return diagnostic
+	}
+
+	diagnostic.Message = lintErr.Err.Error()
+	diagnostic.Range = nodeRange(lines, node)
+
+	return diagnostic
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package lsp
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseDocument parses the YAML nodes of a reviewpad file, keeping their positions.
+// It returns nil when the document is not a YAML mapping.
+func parseDocument(text string) *yaml.Node {
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &document); err != nil || len(document.Content) == 0 {
+		return nil
+	}
+
+	if document.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+
+	return document.Content[0]
+}
+
+// resolvePath finds the node of a path in a reviewpad file, e.g. workflows[0].if[1].extra-actions[0].
+func resolvePath(file *yaml.Node, path string) *yaml.Node {
+	node := file
+	for _, part := range strings.Split(path, ".") {
+		key := part
+		index := -1
+		if i := strings.Index(part, "["); i >= 0 && strings.HasSuffix(part, "]") {
+			key = part[:i]
+
+			var err error
+			index, err = strconv.Atoi(part[i+1 : len(part)-1])
+			if err != nil {
+				return nil
+			}
+		}
+
+		node = mappingValue(node, key)
+		if index < 0 {
+			continue
+		}
+
+		items := sequenceItems(node)
+		if index >= len(items) {
+			return nil
+		}
+
+		node = items[index]
+	}
+
+	return node
+}
+
+// specPosition converts a byte offset in the value of a scalar node, i.e. in a spec, to a position in the document.
+// Characters are counted as runes, which are UTF-16 code units except for the characters outside of the basic plane.
+func specPosition(lines []string, node *yaml.Node, offset int) Position {
+	if offset > len(node.Value) {
+		offset = len(node.Value)
+	}
+
+	prefix := node.Value[:offset]
+	specLine := strings.Count(prefix, "\n")
+	specCharacter := utf8.RuneCountInString(prefix[strings.LastIndex(prefix, "\n")+1:])
+
+	switch node.Style {
+	case yaml.LiteralStyle, yaml.FoldedStyle:
+		// The content of block scalars starts in the line after their header, e.g. |-.
+		line := node.Line + specLine
+		indent := 0
+		if line < len(lines) {
+			indent = len(lines[line]) - len(strings.TrimLeft(lines[line], " "))
+		}
+
+		return Position{Line: line, Character: indent + specCharacter}
+	case yaml.DoubleQuotedStyle, yaml.SingleQuotedStyle:
+		if specLine == 0 {
+			return Position{Line: node.Line - 1, Character: node.Column + specCharacter}
+		}
+	default:
+		if specLine == 0 {
+			return Position{Line: node.Line - 1, Character: node.Column - 1 + specCharacter}
+		}
+	}
+
+	return Position{Line: node.Line - 1 + specLine, Character: specCharacter}
+}
+
+// nodeRange is the range of the value of a scalar node in the document.
+func nodeRange(lines []string, node *yaml.Node) Range {
+	return Range{
+		Start: specPosition(lines, node, 0),
+		End:   specPosition(lines, node, len(node.Value)),
+	}
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	return node.Content
+}
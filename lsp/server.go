@@ -0,0 +1,306 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+// Package lsp implements a language server for reviewpad files.
+// Besides the diagnostics of the specs, it completes the names of the built-ins
+// and finds the definitions of the rules and groups mentioned in the specs.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	gh "github.com/reviewpad/reviewpad/v3/codehost/github"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+// diagnosticsDelay is the time without changes to a document after which its diagnostics are published.
+const diagnosticsDelay = 300 * time.Millisecond
+
+// Server answers the messages of the editor in the order they are read.
+// The diagnostics are published in the background since loading a document can fetch its remote imports.
+type Server struct {
+	builtIns *aladino.BuiltIns
+	// githubClient fetches the files imported from repositories.
+	githubClient *gh.GithubClient
+	// mu guards the documents, their versions and the timers of their diagnostics.
+	mu sync.Mutex
+	// documents are the contents of the open documents by uri.
+	documents map[string]string
+	// versions count the changes of the documents by uri, so that outdated diagnostics are not published.
+	versions map[string]int
+	// timers publish the diagnostics of the documents by uri once they stop changing.
+	timers map[string]*time.Timer
+	// diagnosing are the diagnostics being published.
+	diagnosing sync.WaitGroup
+	// lintMu allows a single document to be linted at a time.
+	lintMu sync.Mutex
+	// outMu guards the writes of the messages.
+	outMu sync.Mutex
+	out   io.Writer
+}
+
+func NewServer(builtIns *aladino.BuiltIns, githubClient *gh.GithubClient) *Server {
+	return &Server{
+		builtIns:     builtIns,
+		githubClient: githubClient,
+		documents:    make(map[string]string),
+		versions:     make(map[string]int),
+		timers:       make(map[string]*time.Timer),
+	}
+}
+
+// Serve answers the messages read from in until the client asks the server to exit or in is closed.
+// It returns once the diagnostics being published are written.
+func (s *Server) Serve(in io.Reader, out io.Writer) error {
+	s.out = out
+	reader := bufio.NewReader(in)
+
+	defer s.diagnosing.Wait()
+
+	for {
+		content, err := readMessage(reader)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		req := &request{}
+		if err := json.Unmarshal(content, req); err != nil {
+			if err := s.replyError(nil, errorCodeParseError, err.Error()); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if req.Method == "exit" {
+			return nil
+		}
+
+		if err := s.handle(req); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(req *request) error {
+	switch req.Method {
+	case "initialize":
+		return s.reply(req.ID, initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   textDocumentSyncKindFull,
+				CompletionProvider: completionOptions{TriggerCharacters: []string{"$"}},
+				DefinitionProvider: true,
+			},
+			ServerInfo: serverInfo{Name: "reviewpad"},
+		})
+	case "shutdown":
+		return s.reply(req.ID, nil)
+	case "textDocument/didOpen":
+		params := &didOpenTextDocumentParams{}
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return nil
+		}
+
+		s.mu.Lock()
+		s.documents[params.TextDocument.URI] = params.TextDocument.Text
+		s.scheduleDiagnostics(params.TextDocument.URI, 0)
+		s.mu.Unlock()
+
+		return nil
+	case "textDocument/didChange":
+		params := &didChangeTextDocumentParams{}
+		if err := json.Unmarshal(req.Params, params); err != nil || len(params.ContentChanges) == 0 {
+			return nil
+		}
+
+		// The documents are synchronized in full so the last change has the whole document.
+		s.mu.Lock()
+		s.documents[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+		s.scheduleDiagnostics(params.TextDocument.URI, diagnosticsDelay)
+		s.mu.Unlock()
+
+		return nil
+	case "textDocument/didClose":
+		params := &didCloseTextDocumentParams{}
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return nil
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(s.documents, params.TextDocument.URI)
+		s.cancelDiagnostics(params.TextDocument.URI)
+
+		return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []Diagnostic{},
+		})
+	case "textDocument/completion":
+		params := &textDocumentPositionParams{}
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return s.replyError(req.ID, errorCodeInvalidParams, err.Error())
+		}
+
+		return s.reply(req.ID, Complete(s.builtIns, s.document(params.TextDocument.URI), params.Position))
+	case "textDocument/definition":
+		params := &textDocumentPositionParams{}
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return s.replyError(req.ID, errorCodeInvalidParams, err.Error())
+		}
+
+		definition := FindDefinition(s.document(params.TextDocument.URI), params.Position)
+		if definition == nil {
+			return s.reply(req.ID, nil)
+		}
+
+		return s.reply(req.ID, Location{URI: params.TextDocument.URI, Range: *definition})
+	}
+
+	// Notifications that are not supported are ignored, e.g. initialized.
+	if req.ID == nil {
+		return nil
+	}
+
+	return s.replyError(req.ID, errorCodeMethodNotFound, fmt.Sprintf("method %v is not supported", req.Method))
+}
+
+func (s *Server) document(uri string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.documents[uri]
+}
+
+// scheduleDiagnostics publishes the diagnostics of a document after the given delay, unless it changes in the meantime.
+// The caller must hold s.mu.
+func (s *Server) scheduleDiagnostics(uri string, delay time.Duration) {
+	s.cancelDiagnostics(uri)
+	version := s.versions[uri]
+
+	s.diagnosing.Add(1)
+	s.timers[uri] = time.AfterFunc(delay, func() {
+		defer s.diagnosing.Done()
+		s.publishDiagnostics(uri, version)
+	})
+}
+
+// cancelDiagnostics discards the diagnostics of a document that are not published yet.
+// The caller must hold s.mu.
+func (s *Server) cancelDiagnostics(uri string) {
+	s.versions[uri]++
+
+	timer, ok := s.timers[uri]
+	if !ok {
+		return
+	}
+
+	delete(s.timers, uri)
+	if timer.Stop() {
+		s.diagnosing.Done()
+	}
+}
+
+// publishDiagnostics publishes the diagnostics of a document if it did not change since the given version.
+func (s *Server) publishDiagnostics(uri string, version int) {
+	s.mu.Lock()
+	text, ok := s.documents[uri]
+	ok = ok && s.versions[uri] == version
+	s.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	s.lintMu.Lock()
+	diagnostics := Diagnose(s.builtIns, s.githubClient, uriPath(uri), text)
+	s.lintMu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.versions[uri] != version {
+		return
+	}
+
+	// A message that cannot be written also fails the next reply, which stops the server.
+	_ = s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnostics,
+	})
+}
+
+// uriPath is the path of a document on disk, used to resolve the local imports of the document.
+// It is empty for the documents that are not on disk, e.g. untitled:Untitled-1.
+func uriPath(uri string) string {
+	documentUrl, err := url.Parse(uri)
+	if err != nil || documentUrl.Scheme != "file" {
+		return ""
+	}
+
+	return documentUrl.Path
+}
+
+func (s *Server) reply(id *json.RawMessage, result interface{}) error {
+	return s.write(response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *Server) replyError(id *json.RawMessage, code int, message string) error {
+	return s.write(errorResponse{JSONRPC: "2.0", ID: id, Error: &responseError{Code: code, Message: message}})
+}
+
+func (s *Server) notify(method string, params interface{}) error {
+	return s.write(notification{JSONRPC: "2.0", Method: method, Params: params})
+}
+
+func (s *Server) write(msg interface{}) error {
+	s.outMu.Lock()
+	defer s.outMu.Unlock()
+
+	return writeMessage(s.out, msg)
+}
+
+// readMessage reads the content of a message, which follows a header with its length, e.g. Content-Length: 42.
+func readMessage(reader *bufio.Reader) ([]byte, error) {
+	header, err := textproto.NewReader(reader).ReadMIMEHeader()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("lsp: invalid message header: %v", err)
+	}
+
+	length, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("lsp: invalid content length: %v", err)
+	}
+
+	content := make([]byte, length)
+	if _, err := io.ReadFull(reader, content); err != nil {
+		return nil, err
+	}
+
+	return content, nil
+}
+
+func writeMessage(out io.Writer, msg interface{}) error {
+	content, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(out, "Content-Length: %v\r\n\r\n%s", len(content), content)
+	return err
+}
@@ -0,0 +1,312 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package lsp_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	"github.com/reviewpad/reviewpad/v3/lsp"
+	"github.com/stretchr/testify/assert"
+)
+
+const mockedDocumentUri = "file:///reviewpad.yml"
+
+const mockedDocument = `groups:
+  - name: owners
+    spec: '["john", $returnStr("jane")]'
+rules:
+  - name: is-owner
+    spec: |
+      $returnStr("john") in $group("owners")
+workflows:
+  - name: review
+    if:
+      - is-owner
+    then:
+      - $emptyAction()
+`
+
+func mockBuiltIns() *aladino.BuiltIns {
+	builtIns := aladino.MockBuiltIns()
+	builtIns.Functions["group"] = &aladino.BuiltInFunction{
+		Type: aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType()}, aladino.BuildArrayOfType(aladino.BuildStringType())),
+		Code: func(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+			return nil, nil
+		},
+	}
+
+	return builtIns
+}
+
+func mockMessages(t *testing.T, messages ...string) io.Reader {
+	var in bytes.Buffer
+	for _, message := range messages {
+		fmt.Fprintf(&in, "Content-Length: %v\r\n\r\n%v", len(message), message)
+	}
+
+	return &in
+}
+
+func didOpen(t *testing.T, text string) string {
+	encodedText, err := json.Marshal(text)
+	if err != nil {
+		assert.FailNow(t, "json marshal failed", err)
+	}
+
+	return fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":"%v","text":%s}}}`, mockedDocumentUri, encodedText)
+}
+
+func positionRequest(id int, method string, line, character int) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":%v,"method":"%v","params":{"textDocument":{"uri":"%v"},"position":{"line":%v,"character":%v}}}`, id, method, mockedDocumentUri, line, character)
+}
+
+// serve runs the server on the messages and returns the messages it sent.
+func serve(t *testing.T, messages ...string) []map[string]interface{} {
+	var out bytes.Buffer
+	err := lsp.NewServer(mockBuiltIns(), nil).Serve(mockMessages(t, messages...), &out)
+	if err != nil {
+		assert.FailNow(t, "serve failed", err)
+	}
+
+	reader := bufio.NewReader(&out)
+	sent := make([]map[string]interface{}, 0)
+	for {
+		header, err := textproto.NewReader(reader).ReadMIMEHeader()
+		if err == io.EOF {
+			return sent
+		}
+
+		length, _ := strconv.Atoi(header.Get("Content-Length"))
+		content := make([]byte, length)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			assert.FailNow(t, "read failed", err)
+		}
+
+		message := make(map[string]interface{})
+		if err := json.Unmarshal(content, &message); err != nil {
+			assert.FailNow(t, "json unmarshal failed", err)
+		}
+
+		sent = append(sent, message)
+	}
+}
+
+// replyTo is the reply to the request with the given id.
+func replyTo(t *testing.T, sent []map[string]interface{}, id int) map[string]interface{} {
+	for _, message := range sent {
+		if message["id"] == float64(id) {
+			return message
+		}
+	}
+
+	assert.FailNow(t, "reply not found", id)
+	return nil
+}
+
+// publishedDiagnostics are the params of the diagnostics published, in order.
+// The diagnostics are published in the background, so they can be sent before or after the replies.
+func publishedDiagnostics(sent []map[string]interface{}) []map[string]interface{} {
+	published := make([]map[string]interface{}, 0)
+	for _, message := range sent {
+		if message["method"] == "textDocument/publishDiagnostics" {
+			published = append(published, message["params"].(map[string]interface{}))
+		}
+	}
+
+	return published
+}
+
+func asJSON(t *testing.T, value interface{}) string {
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		assert.FailNow(t, "json marshal failed", err)
+	}
+
+	return string(encodedValue)
+}
+
+func TestServe_OnInitialize(t *testing.T) {
+	sent := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	)
+
+	assert.Len(t, sent, 2)
+	assert.JSONEq(t, `{"textDocumentSync":1,"completionProvider":{"triggerCharacters":["$"]},"definitionProvider":true}`, asJSON(t, sent[0]["result"].(map[string]interface{})["capabilities"]))
+	assert.Equal(t, map[string]interface{}{"jsonrpc": "2.0", "id": float64(2), "result": nil}, sent[1])
+}
+
+func TestServe_WhenDocumentIsValid(t *testing.T) {
+	sent := serve(t, didOpen(t, mockedDocument))
+
+	assert.JSONEq(t, `{"uri":"file:///reviewpad.yml","diagnostics":[]}`, asJSON(t, publishedDiagnostics(sent)[0]))
+}
+
+func TestServe_WhenSpecHasTypeError(t *testing.T) {
+	tests := map[string]struct {
+		document  string
+		wantRange string
+	}{
+		"flow scalar": {
+			document: `groups:
+  - name: owners
+    spec: '["john", $returnStr(1)]'
+`,
+			wantRange: `{"start":{"line":2,"character":20},"end":{"line":2,"character":33}}`,
+		},
+		"block scalar": {
+			document: `rules:
+  - name: is-zero
+    spec: |
+      $zeroConst() == 0 && $returnStr(0) == "0"
+workflows:
+  - name: review
+    if:
+      - is-zero
+    then:
+      - $emptyAction()
+`,
+			wantRange: `{"start":{"line":3,"character":27},"end":{"line":3,"character":40}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sent := serve(t, didOpen(t, test.document))
+
+			diagnostics := publishedDiagnostics(sent)[0]["diagnostics"].([]interface{})
+
+			assert.Len(t, diagnostics, 1)
+			assert.Equal(t, "type inference failed: mismatch in arg types on returnStr", diagnostics[0].(map[string]interface{})["message"])
+			assert.JSONEq(t, test.wantRange, asJSON(t, diagnostics[0].(map[string]interface{})["range"]))
+		})
+	}
+}
+
+func TestServe_WhenDocumentIsNotYAML(t *testing.T) {
+	sent := serve(t, didOpen(t, "rules:\n  - name: [\n"))
+
+	diagnostics := publishedDiagnostics(sent)[0]["diagnostics"].([]interface{})
+
+	assert.Len(t, diagnostics, 1)
+	assert.Contains(t, diagnostics[0].(map[string]interface{})["message"], "yaml: line")
+}
+
+func TestServe_OnCompletion(t *testing.T) {
+	sent := serve(t,
+		didOpen(t, mockedDocument),
+		// $returnStr("john") in $group("owners") with the cursor after $re
+		positionRequest(1, "textDocument/completion", 6, 9),
+	)
+
+	assert.JSONEq(t, `[{"label":"returnStr","kind":3,"detail":"(String) => String"}]`, asJSON(t, replyTo(t, sent, 1)["result"]))
+}
+
+func TestServe_OnCompletion_WhenImportIsSlow(t *testing.T) {
+	t.Setenv("REVIEWPAD_CACHE_DIR", t.TempDir())
+
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", "https://foo.bar/reviewpad.yml", func(req *http.Request) (*http.Response, error) {
+		time.Sleep(100 * time.Millisecond)
+		return httpmock.NewStringResponse(200, "api-version: reviewpad.com/v1alpha\n"), nil
+	})
+
+	sent := serve(t,
+		didOpen(t, "imports:\n  - url: https://foo.bar/reviewpad.yml\n"+mockedDocument),
+		positionRequest(1, "textDocument/completion", 8, 9),
+	)
+
+	// The completion is answered while the import is fetched.
+	assert.Len(t, sent, 2)
+	assert.Equal(t, float64(1), sent[0]["id"])
+	assert.JSONEq(t, `{"uri":"file:///reviewpad.yml","diagnostics":[]}`, asJSON(t, publishedDiagnostics(sent)[0]))
+}
+
+func TestServe_OnDidChange(t *testing.T) {
+	didChange := func(text string) string {
+		return fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didChange","params":{"textDocument":{"uri":"%v"},"contentChanges":[{"text":%v}]}}`, mockedDocumentUri, asJSON(t, text))
+	}
+
+	sent := serve(t,
+		didOpen(t, mockedDocument),
+		didChange("rules:\n  - name: [\n"),
+		didChange(mockedDocument),
+	)
+
+	// Only the diagnostics of the last change are published once the document stops changing.
+	published := publishedDiagnostics(sent)
+	assert.NotEmpty(t, published)
+	for _, diagnostics := range published {
+		assert.JSONEq(t, `{"uri":"file:///reviewpad.yml","diagnostics":[]}`, asJSON(t, diagnostics))
+	}
+}
+
+func TestServe_OnDidClose(t *testing.T) {
+	sent := serve(t,
+		didOpen(t, "rules:\n  - name: [\n"),
+		fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didClose","params":{"textDocument":{"uri":"%v"}}}`, mockedDocumentUri),
+	)
+
+	// The diagnostics of a closed document are cleared.
+	published := publishedDiagnostics(sent)
+	assert.JSONEq(t, `{"uri":"file:///reviewpad.yml","diagnostics":[]}`, asJSON(t, published[len(published)-1]))
+}
+
+func TestServe_OnDefinition(t *testing.T) {
+	tests := map[string]struct {
+		position  lsp.Position
+		wantRange string
+	}{
+		"group mention": {
+			position:  lsp.Position{Line: 6, Character: 32},
+			wantRange: `{"start":{"line":1,"character":10},"end":{"line":1,"character":16}}`,
+		},
+		"workflow rule": {
+			position:  lsp.Position{Line: 10, Character: 10},
+			wantRange: `{"start":{"line":4,"character":10},"end":{"line":4,"character":18}}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sent := serve(t,
+				didOpen(t, mockedDocument),
+				positionRequest(1, "textDocument/definition", test.position.Line, test.position.Character),
+			)
+
+			assert.JSONEq(t, test.wantRange, asJSON(t, replyTo(t, sent, 1)["result"].(map[string]interface{})["range"]))
+		})
+	}
+}
+
+func TestServe_WhenDefinitionIsNotFound(t *testing.T) {
+	sent := serve(t,
+		didOpen(t, mockedDocument),
+		positionRequest(1, "textDocument/definition", 0, 0),
+	)
+
+	assert.Nil(t, replyTo(t, sent, 1)["result"])
+}
+
+func TestServe_WhenMethodIsNotSupported(t *testing.T) {
+	sent := serve(t, `{"jsonrpc":"2.0","id":1,"method":"textDocument/hover","params":{}}`)
+
+	assert.JSONEq(t, `{"code":-32601,"message":"method textDocument/hover is not supported"}`, asJSON(t, sent[0]["error"]))
+}
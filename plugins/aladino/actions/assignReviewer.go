@@ -16,7 +16,7 @@ import (
 
 func AssignReviewer() *aladino.BuiltInAction {
 	return &aladino.BuiltInAction{
-		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildArrayOfType(aladino.BuildStringType()), aladino.BuildIntType()}, nil),
+		Type:           aladino.BuildFunctionTypeWithDefaults([]aladino.Type{aladino.BuildArrayOfType(aladino.BuildStringType()), aladino.BuildIntType()}, nil, []aladino.Value{aladino.BuildIntValue(99)}),
 		Code:           assignReviewerCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
 	}
@@ -62,6 +62,10 @@ func assignReviewerCode(e aladino.Env, args []aladino.Value) error {
 
 	// Re-request current reviewers if mention on the provided reviewers list
 	for _, review := range reviews {
+		if review.User == nil {
+			continue
+		}
+
 		for index, availableReviewer := range availableReviewers {
 			if availableReviewer.(*aladino.StringValue).Val == review.User.Login {
 				totalRequiredReviewers--
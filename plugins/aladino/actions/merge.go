@@ -14,7 +14,7 @@ import (
 
 func Merge() *aladino.BuiltInAction {
 	return &aladino.BuiltInAction{
-		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType()}, nil),
+		Type:           aladino.BuildFunctionTypeWithDefaults([]aladino.Type{aladino.BuildStringType()}, nil, []aladino.Value{aladino.BuildStringValue("merge")}),
 		Code:           mergeCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
 	}
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/reviewpad/reviewpad/v3/engine"
 	"github.com/reviewpad/reviewpad/v3/lang/aladino"
 	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
 	"github.com/stretchr/testify/assert"
@@ -90,3 +91,33 @@ func TestMerge_WhenMergeMethodIsProvided(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, wantMergeMethod, gotMergeMethod)
 }
+
+func TestMerge_WhenMergeMethodIsOmitted(t *testing.T) {
+	var gotMergeMethod string
+	mockedEnv := aladino.MockDefaultEnv(
+		t,
+		[]mock.MockBackendOption{
+			mock.WithRequestMatchHandler(
+				mock.PutReposPullsMergeByOwnerByRepoByPullNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					rawBody, _ := ioutil.ReadAll(r.Body)
+					body := MergeRequestPostBody{}
+
+					json.Unmarshal(rawBody, &body)
+
+					gotMergeMethod = body.MergeMethod
+				}),
+			),
+		},
+		nil,
+		plugins_aladino.PluginBuiltIns(),
+		nil,
+	)
+
+	interpreter := &aladino.Interpreter{Env: mockedEnv}
+
+	err := interpreter.ExecStatement(engine.BuildStatement("$merge()"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, "merge", gotMergeMethod)
+}
@@ -60,6 +60,7 @@ func PluginBuiltInsWithConfig(config *PluginConfig) *aladino.BuiltIns {
 			"commentCount":          functions.CommentCount(),
 			"comments":              functions.Comments(),
 			"commitCount":           functions.CommitCount(),
+			"commitDetails":         functions.CommitDetails(),
 			"commits":               functions.Commits(),
 			"createdAt":             functions.CreatedAt(),
 			"description":           functions.Description(),
@@ -79,6 +80,7 @@ func PluginBuiltInsWithConfig(config *PluginConfig) *aladino.BuiltIns {
 			"lastEventAt":           functions.LastEventAt(),
 			"milestone":             functions.Milestone(),
 			"reviewers":             functions.Reviewers(),
+			"reviews":               functions.Reviews(),
 			"reviewerStatus":        functions.ReviewerStatus(),
 			"size":                  functions.Size(),
 			"title":                 functions.Title(),
@@ -91,17 +93,32 @@ func PluginBuiltInsWithConfig(config *PluginConfig) *aladino.BuiltIns {
 			"pullRequestCountBy":       functions.PullRequestCountBy(),
 			"totalCreatedPullRequests": functions.TotalCreatedPullRequests(),
 			// Utilities
-			"append":      functions.AppendString(),
-			"contains":    functions.Contains(),
-			"isElementOf": functions.IsElementOf(),
-			"startsWith":  functions.StartsWith(),
-			"length":      functions.Length(),
-			"sprintf":     functions.Sprintf(),
+			"append":         functions.AppendString(),
+			"contains":       functions.Contains(),
+			"extract":        functions.Extract(),
+			"formatDuration": functions.FormatDuration(),
+			"formatTime":     functions.FormatTime(),
+			"isElementOf":    functions.IsElementOf(),
+			"matches":        functions.Matches(),
+			"now":            functions.Now(),
+			"replace":        functions.Replace(),
+			"startsWith":     functions.StartsWith(),
+			"length":         functions.Length(),
+			"sprintf":        functions.Sprintf(),
+			// Collections
+			"all":        functions.All(),
+			"any":        functions.Any(),
+			"count":      functions.Count(),
+			"difference": functions.Difference(),
+			"filter":     functions.Filter(),
+			"flatten":    functions.Flatten(),
+			"intersect":  functions.Intersect(),
+			"map":        functions.Map(),
+			"sort":       functions.Sort(),
+			"unique":     functions.Unique(),
 			// Engine
 			"group": functions.Group(),
 			"rule":  functions.Rule(),
-			// Internal
-			"filter": functions.Filter(),
 		},
 		Actions: map[string]*aladino.BuiltInAction{
 			"addToProject":         actions.AddToProject(),
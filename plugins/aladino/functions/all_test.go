@@ -0,0 +1,74 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var allFn = plugins_aladino.PluginBuiltIns().Functions["all"].Code
+
+func TestAll_WhenAllElementsSatisfyPredicate(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	isEven := aladino.BuildFunctionValue(func(args []aladino.Value) (aladino.Value, error) {
+		return aladino.BuildBoolValue(args[0].(*aladino.IntValue).Val%2 == 0), nil
+	})
+	gotVal, err := allFn(mockedEnv, []aladino.Value{intValues(2, 4), isEven})
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildBoolValue(true), gotVal)
+}
+
+func TestAll_WhenSomeElementDoesNotSatisfyPredicate(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	isEven := aladino.BuildFunctionValue(func(args []aladino.Value) (aladino.Value, error) {
+		return aladino.BuildBoolValue(args[0].(*aladino.IntValue).Val%2 == 0), nil
+	})
+	gotVal, err := allFn(mockedEnv, []aladino.Value{intValues(2, 3), isEven})
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildBoolValue(false), gotVal)
+}
+
+func TestAll_WhenArrayIsEmpty(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	isEven := aladino.BuildFunctionValue(func(args []aladino.Value) (aladino.Value, error) {
+		return aladino.BuildBoolValue(args[0].(*aladino.IntValue).Val%2 == 0), nil
+	})
+	gotVal, err := allFn(mockedEnv, []aladino.Value{intValues(), isEven})
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildBoolValue(true), gotVal)
+}
+
+func TestAll_WhenLambdaFails(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := allFn(mockedEnv, []aladino.Value{intValues(1, 2), failingFn})
+
+	assert.EqualError(t, err, "eval: division by zero")
+	assert.Nil(t, gotVal)
+}
+
+func TestAll_WhenArrayLiteralIsEmpty(t *testing.T) {
+	gotVal, err := evalSpec(t, `$all([], ($n: Int => $n > 1))`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildBoolValue(true), gotVal)
+}
+
+func TestAll_WhenLambdaParameterCannotBeInferred(t *testing.T) {
+	gotVal, err := evalSpec(t, `$all([], ($r => $r == 1))`)
+
+	assert.EqualError(t, err, "type inference failed: cannot infer type of parameter r")
+	assert.Nil(t, gotVal)
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+func Any() *aladino.BuiltInFunction {
+	elemType := aladino.BuildTypeVariable("A")
+	return &aladino.BuiltInFunction{
+		Type: aladino.BuildFunctionType(
+			[]aladino.Type{
+				aladino.BuildArrayOfType(elemType),
+				aladino.BuildFunctionType(
+					[]aladino.Type{elemType},
+					aladino.BuildBoolType(),
+				),
+			},
+			aladino.BuildBoolType(),
+		),
+		Code:           anyCode,
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+func anyCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	elems := args[0].(*aladino.ArrayValue).Vals
+	fn := args[1].(*aladino.FunctionValue).Fn
+
+	for _, elem := range elems {
+		holds, err := fn([]aladino.Value{elem})
+		if err != nil {
+			return nil, err
+		}
+
+		if holds.(*aladino.BoolValue).Val {
+			return aladino.BuildBoolValue(true), nil
+		}
+	}
+
+	return aladino.BuildBoolValue(false), nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var anyFn = plugins_aladino.PluginBuiltIns().Functions["any"].Code
+
+func TestAny_WhenSomeElementSatisfiesPredicate(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	isEven := aladino.BuildFunctionValue(func(args []aladino.Value) (aladino.Value, error) {
+		return aladino.BuildBoolValue(args[0].(*aladino.IntValue).Val%2 == 0), nil
+	})
+	gotVal, err := anyFn(mockedEnv, []aladino.Value{intValues(1, 2, 3), isEven})
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildBoolValue(true), gotVal)
+}
+
+func TestAny_WhenNoElementSatisfiesPredicate(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	isEven := aladino.BuildFunctionValue(func(args []aladino.Value) (aladino.Value, error) {
+		return aladino.BuildBoolValue(args[0].(*aladino.IntValue).Val%2 == 0), nil
+	})
+	gotVal, err := anyFn(mockedEnv, []aladino.Value{intValues(1, 3), isEven})
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildBoolValue(false), gotVal)
+}
+
+func TestAny_WhenLambdaParameterIsInferred(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, plugins_aladino.PluginBuiltIns(), nil)
+
+	expr, err := aladino.Parse(`$any($map([1, 2, 3], ($n => $n * 2)), ($n => $n > 5))`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	_, err = aladino.TypeInference(mockedEnv, expr)
+	assert.Nil(t, err)
+
+	gotVal, err := aladino.Eval(mockedEnv, expr)
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildBoolValue(true), gotVal)
+}
+
+func TestAny_WhenLambdaFails(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := anyFn(mockedEnv, []aladino.Value{intValues(1, 2), failingFn})
+
+	assert.EqualError(t, err, "eval: division by zero")
+	assert.Nil(t, gotVal)
+}
+
+func TestAny_WhenLambdaBodyEvalFails(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, plugins_aladino.PluginBuiltIns(), nil)
+
+	expr, err := aladino.Parse(`$any([1, 2], ($x => $x / 0 > 0))`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	_, err = aladino.TypeInference(mockedEnv, expr)
+	assert.Nil(t, err)
+
+	gotVal, err := aladino.Eval(mockedEnv, expr)
+
+	assert.ErrorContains(t, err, "eval: division by zero")
+	assert.Nil(t, gotVal)
+}
+
+func TestAny_WhenArrayLiteralIsEmpty(t *testing.T) {
+	gotVal, err := evalSpec(t, `$any([], ($n: Int => $n > 1))`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildBoolValue(false), gotVal)
+}
@@ -5,6 +5,7 @@
 package plugins_aladino_functions
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -17,6 +18,7 @@ func Changed() *aladino.BuiltInFunction {
 	return &aladino.BuiltInFunction{
 		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType(), aladino.BuildStringType()}, aladino.BuildBoolType()),
 		Code:           changedCode,
+		RegexParams:    []int{0, 1},
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
 	}
 }
@@ -27,8 +29,15 @@ func changedCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
 	antecedentRegex := args[0].(*aladino.StringValue).Val
 	consequentRegex := args[1].(*aladino.StringValue).Val
 
-	antecedentMatches := getMatches(pullRequest, antecedentRegex)
-	consequentMatches := getMatches(pullRequest, consequentRegex)
+	antecedentMatches, err := getMatches(pullRequest, antecedentRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	consequentMatches, err := getMatches(pullRequest, consequentRegex)
+	if err != nil {
+		return nil, err
+	}
 
 	retValue := aladino.BuildTrueValue()
 
@@ -55,9 +64,12 @@ func changedCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
 	return retValue, nil
 }
 
-func getMatches(pullRequest *target.PullRequestTarget, pattern string) map[string][]string {
+func getMatches(pullRequest *target.PullRequestTarget, pattern string) (map[string][]string, error) {
 	resolvedPattern, vars := interpolateRegex(pattern)
-	re := regexp.MustCompile(resolvedPattern)
+	re, err := regexp.Compile(resolvedPattern)
+	if err != nil {
+		return nil, fmt.Errorf("changed: invalid pattern %q: %v", pattern, err)
+	}
 
 	valsMatrix := make(map[string][]string, 0)
 
@@ -76,7 +88,7 @@ func getMatches(pullRequest *target.PullRequestTarget, pattern string) map[strin
 		}
 	}
 
-	return valsMatrix
+	return valsMatrix, nil
 }
 
 func interpolateRegex(s string) (string, []string) {
@@ -86,3 +86,20 @@ func TestChanged(t *testing.T) {
 		})
 	}
 }
+
+func TestChanged_WhenInvalidPattern(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	args := []aladino.Value{aladino.BuildStringValue("src/(@1.go"), aladino.BuildStringValue("docs/@1.md")}
+
+	gotVal, err := changed(mockedEnv, args)
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "changed: invalid pattern \"src/(@1.go\": error parsing regexp: missing closing ): `src/((.*).go`")
+}
+
+func TestChanged_WhenRegexLiteralIsInvalid(t *testing.T) {
+	err := lintRule(`$changed("src/@1.go", "(")`)
+
+	assert.ErrorContains(t, err, "[lint] rules[0].spec: invalid regex \"(\"")
+}
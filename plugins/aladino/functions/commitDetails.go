@@ -0,0 +1,52 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"github.com/reviewpad/reviewpad/v3/codehost/github/target"
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+// CommitDetails returns the sha, the author and the message of the commits,
+// while $commits returns only their messages.
+// The author is given by the login of its GitHub account, which is empty when the commit is not linked to an account,
+// and by its name in git.
+func CommitDetails() *aladino.BuiltInFunction {
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{}, aladino.BuildArrayOfType(commitType())),
+		Code:           commitDetailsCode,
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+	}
+}
+
+func commitDetailsCode(e aladino.Env, _ []aladino.Value) (aladino.Value, error) {
+	t := e.GetTarget().(*target.PullRequestTarget)
+	ghCommits, err := t.GetCommits()
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]aladino.Value, len(ghCommits))
+	for i, ghCommit := range ghCommits {
+		commits[i] = aladino.BuildRecordValue(map[string]aladino.Value{
+			"sha":     aladino.BuildStringValue(ghCommit.SHA),
+			"login":   aladino.BuildStringValue(ghCommit.Author.Login),
+			"name":    aladino.BuildStringValue(ghCommit.AuthorName),
+			"message": aladino.BuildStringValue(ghCommit.Message),
+		})
+	}
+
+	return aladino.BuildArrayValue(commits), nil
+}
+
+func commitType() *aladino.RecordType {
+	return aladino.BuildRecordType(map[string]aladino.Type{
+		"sha":     aladino.BuildStringType(),
+		"login":   aladino.BuildStringType(),
+		"name":    aladino.BuildStringType(),
+		"message": aladino.BuildStringType(),
+	})
+}
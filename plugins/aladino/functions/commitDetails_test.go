@@ -0,0 +1,105 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v45/github"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var commitDetails = plugins_aladino.PluginBuiltIns().Functions["commitDetails"].Code
+
+func TestCommitDetails_WhenListCommitsRequestFails(t *testing.T) {
+	failMessage := "ListCommitsRequestFail"
+	mockedEnv := aladino.MockDefaultEnv(
+		t,
+		[]mock.MockBackendOption{
+			mock.WithRequestMatchHandler(
+				mock.GetReposPullsCommitsByOwnerByRepoByPullNumber,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					mock.WriteError(
+						w,
+						http.StatusInternalServerError,
+						failMessage,
+					)
+				}),
+			),
+		},
+		nil,
+		aladino.MockBuiltIns(),
+		nil,
+	)
+
+	args := []aladino.Value{}
+	gotCommits, err := commitDetails(mockedEnv, args)
+
+	assert.Nil(t, gotCommits)
+	assert.Equal(t, err.(*github.ErrorResponse).Message, failMessage)
+}
+
+func TestCommitDetails(t *testing.T) {
+	repoCommits := []*github.RepositoryCommit{
+		{
+			SHA: github.String("abc123"),
+			Author: &github.User{
+				Login: github.String("john"),
+			},
+			Commit: &github.Commit{
+				Author: &github.CommitAuthor{
+					Name: github.String("John Doe"),
+				},
+				Message: github.String("Lorem Ipsum"),
+			},
+		},
+		{
+			SHA: github.String("def456"),
+			Commit: &github.Commit{
+				Author: &github.CommitAuthor{
+					Name: github.String("Jane Doe"),
+				},
+				Message: github.String("Dolor sit amet"),
+			},
+		},
+	}
+	mockedEnv := aladino.MockDefaultEnv(
+		t,
+		[]mock.MockBackendOption{
+			mock.WithRequestMatch(
+				mock.GetReposPullsCommitsByOwnerByRepoByPullNumber,
+				repoCommits,
+			),
+		},
+		nil,
+		aladino.MockBuiltIns(),
+		nil,
+	)
+
+	wantCommits := aladino.BuildArrayValue([]aladino.Value{
+		aladino.BuildRecordValue(map[string]aladino.Value{
+			"sha":     aladino.BuildStringValue("abc123"),
+			"login":   aladino.BuildStringValue("john"),
+			"name":    aladino.BuildStringValue("John Doe"),
+			"message": aladino.BuildStringValue("Lorem Ipsum"),
+		}),
+		aladino.BuildRecordValue(map[string]aladino.Value{
+			"sha":     aladino.BuildStringValue("def456"),
+			"login":   aladino.BuildStringValue(""),
+			"name":    aladino.BuildStringValue("Jane Doe"),
+			"message": aladino.BuildStringValue("Dolor sit amet"),
+		}),
+	})
+
+	args := []aladino.Value{}
+	gotCommits, err := commitDetails(mockedEnv, args)
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantCommits, gotCommits)
+}
@@ -0,0 +1,43 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var count = plugins_aladino.PluginBuiltIns().Functions["count"].Code
+
+func TestCount(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	isEven := aladino.BuildFunctionValue(func(args []aladino.Value) (aladino.Value, error) {
+		return aladino.BuildBoolValue(args[0].(*aladino.IntValue).Val%2 == 0), nil
+	})
+	gotVal, err := count(mockedEnv, []aladino.Value{intValues(1, 2, 3, 4), isEven})
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildIntValue(2), gotVal)
+}
+
+func TestCount_WhenLambdaFails(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := count(mockedEnv, []aladino.Value{intValues(1, 2), failingFn})
+
+	assert.EqualError(t, err, "eval: division by zero")
+	assert.Nil(t, gotVal)
+}
+
+func TestCount_WhenArrayLiteralIsEmpty(t *testing.T) {
+	gotVal, err := evalSpec(t, `$count([], ($n: Int => $n > 1))`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildIntValue(0), gotVal)
+}
@@ -13,7 +13,7 @@ import (
 
 func CreatedAt() *aladino.BuiltInFunction {
 	return &aladino.BuiltInFunction{
-		Type:           aladino.BuildFunctionType([]aladino.Type{}, aladino.BuildIntType()),
+		Type:           aladino.BuildFunctionType([]aladino.Type{}, aladino.BuildTimeType()),
 		Code:           createdAtCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
 	}
@@ -29,5 +29,5 @@ func createdAtCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
 	if err != nil {
 		return nil, err
 	}
-	return aladino.BuildIntValue(int(createdAtTime.Unix())), nil
+	return aladino.BuildTimeValue(int(createdAtTime.Unix())), nil
 }
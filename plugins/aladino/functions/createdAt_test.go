@@ -42,7 +42,7 @@ func TestCreatedAt(t *testing.T) {
 	if err != nil {
 		assert.FailNow(t, "time.Parse failed", err)
 	}
-	wantCreatedAt := aladino.BuildIntValue(int(wantCreatedAtTime.Unix()))
+	wantCreatedAt := aladino.BuildTimeValue(int(wantCreatedAtTime.Unix()))
 
 	args := []aladino.Value{}
 	gotCreatedAt, err := createdAt(mockedEnv, args)
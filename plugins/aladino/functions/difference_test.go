@@ -0,0 +1,31 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var difference = plugins_aladino.PluginBuiltIns().Functions["difference"].Code
+
+func TestDifference(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := difference(mockedEnv, []aladino.Value{intValues(1, 2, 3, 4), intValues(4, 2, 5)})
+
+	assert.Nil(t, err)
+	assert.Equal(t, intValues(1, 3), gotVal)
+}
+
+func TestDifference_WhenArrayLiteralIsEmpty(t *testing.T) {
+	gotVal, err := evalSpec(t, `$length($difference([], []))`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildIntValue(0), gotVal)
+}
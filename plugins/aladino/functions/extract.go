@@ -0,0 +1,46 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+func Extract() *aladino.BuiltInFunction {
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType(), aladino.BuildStringType()}, aladino.BuildStringType()),
+		Code:           extractCode,
+		RegexParams:    []int{1},
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+// extractCode returns the first capturing group of the first match.
+// When the regex has no capturing groups, the whole match is returned instead.
+// If there is no match, the empty string is returned.
+func extractCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	str := args[0].(*aladino.StringValue).Val
+	pattern := args[1].(*aladino.StringValue).Val
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("extract: invalid regex %q: %v", pattern, err)
+	}
+
+	match := re.FindStringSubmatch(str)
+	if match == nil {
+		return aladino.BuildStringValue(""), nil
+	}
+
+	if len(match) > 1 {
+		return aladino.BuildStringValue(match[1]), nil
+	}
+
+	return aladino.BuildStringValue(match[0]), nil
+}
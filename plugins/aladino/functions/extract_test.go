@@ -0,0 +1,66 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var extract = plugins_aladino.PluginBuiltIns().Functions["extract"].Code
+
+func TestExtract(t *testing.T) {
+	tests := map[string]struct {
+		args    []aladino.Value
+		wantVal aladino.Value
+	}{
+		"first capturing group": {
+			args:    []aladino.Value{aladino.BuildStringValue("feat(lang): add regex"), aladino.BuildStringValue("^\\w+\\((\\w+)\\)")},
+			wantVal: aladino.BuildStringValue("lang"),
+		},
+		"whole match without capturing groups": {
+			args:    []aladino.Value{aladino.BuildStringValue("fixes #123 and #456"), aladino.BuildStringValue("#[0-9]+")},
+			wantVal: aladino.BuildStringValue("#123"),
+		},
+		"no match": {
+			args:    []aladino.Value{aladino.BuildStringValue("docs: update readme"), aladino.BuildStringValue("#[0-9]+")},
+			wantVal: aladino.BuildStringValue(""),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+			gotVal, err := extract(mockedEnv, test.args)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantVal, gotVal)
+		})
+	}
+}
+
+func TestExtract_WhenInvalidRegex(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	args := []aladino.Value{
+		aladino.BuildStringValue("feat: add"),
+		aladino.BuildStringValue("[a-"),
+	}
+
+	gotVal, err := extract(mockedEnv, args)
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "extract: invalid regex \"[a-\": error parsing regexp: missing closing ]: `[a-`")
+}
+
+func TestExtract_WhenRegexLiteralIsInvalid(t *testing.T) {
+	err := lintRule(`$extract($title(), "[a-") != ""`)
+
+	assert.ErrorContains(t, err, "[lint] rules[0].spec: invalid regex \"[a-\"")
+}
@@ -10,16 +10,17 @@ import (
 )
 
 func Filter() *aladino.BuiltInFunction {
+	elemType := aladino.BuildTypeVariable("A")
 	return &aladino.BuiltInFunction{
 		Type: aladino.BuildFunctionType(
 			[]aladino.Type{
-				aladino.BuildArrayOfType(aladino.BuildStringType()),
+				aladino.BuildArrayOfType(elemType),
 				aladino.BuildFunctionType(
-					[]aladino.Type{aladino.BuildStringType()},
+					[]aladino.Type{elemType},
 					aladino.BuildBoolType(),
 				),
 			},
-			aladino.BuildArrayOfType(aladino.BuildStringType()),
+			aladino.BuildArrayOfType(elemType),
 		),
 		Code:           filterCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
@@ -32,8 +33,12 @@ func filterCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
 	fn := args[1].(*aladino.FunctionValue).Fn
 
 	for _, elem := range elems {
-		fnResult := fn([]aladino.Value{elem}).(*aladino.BoolValue).Val
-		if fnResult {
+		fnResult, err := fn([]aladino.Value{elem})
+		if err != nil {
+			return nil, err
+		}
+
+		if fnResult.(*aladino.BoolValue).Val {
 			result = append(result, elem)
 		}
 	}
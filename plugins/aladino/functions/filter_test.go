@@ -20,8 +20,8 @@ func TestFilter(t *testing.T) {
 
 	args := []aladino.Value{
 		aladino.BuildArrayValue([]aladino.Value{aladino.BuildStringValue("1"), mockedIntValue}),
-		aladino.BuildFunctionValue(func(args []aladino.Value) aladino.Value {
-			return aladino.BuildBoolValue(args[0].HasKindOf(aladino.INT_VALUE))
+		aladino.BuildFunctionValue(func(args []aladino.Value) (aladino.Value, error) {
+			return aladino.BuildBoolValue(args[0].HasKindOf(aladino.INT_VALUE)), nil
 		}),
 	}
 	gotElems, err := filter(mockedEnv, args)
@@ -31,3 +31,19 @@ func TestFilter(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, wantElems, gotElems)
 }
+
+func TestFilter_WhenLambdaFails(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := filter(mockedEnv, []aladino.Value{intValues(1, 2), failingFn})
+
+	assert.EqualError(t, err, "eval: division by zero")
+	assert.Nil(t, gotVal)
+}
+
+func TestFilter_WhenArrayLiteralIsEmpty(t *testing.T) {
+	gotVal, err := evalSpec(t, `$filter([], ($n: Int => $n > 1))`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, intValues(), gotVal)
+}
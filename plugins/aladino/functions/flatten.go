@@ -0,0 +1,30 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+func Flatten() *aladino.BuiltInFunction {
+	elemType := aladino.BuildTypeVariable("A")
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildArrayOfType(aladino.BuildArrayOfType(elemType))}, aladino.BuildArrayOfType(elemType)),
+		Code:           flattenCode,
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+func flattenCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	arrays := args[0].(*aladino.ArrayValue).Vals
+
+	result := make([]aladino.Value, 0)
+	for _, array := range arrays {
+		result = append(result, array.(*aladino.ArrayValue).Vals...)
+	}
+
+	return aladino.BuildArrayValue(result), nil
+}
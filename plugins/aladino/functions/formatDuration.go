@@ -0,0 +1,25 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+// FormatDuration formats a duration in days, hours, minutes and seconds, e.g. "2 days 1 hour".
+func FormatDuration() *aladino.BuiltInFunction {
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildDurationType()}, aladino.BuildStringType()),
+		Code:           formatDurationCode,
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+func formatDurationCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	duration := args[0].(*aladino.DurationValue)
+
+	return aladino.BuildStringValue(duration.String()), nil
+}
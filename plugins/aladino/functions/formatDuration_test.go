@@ -0,0 +1,28 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var formatDuration = plugins_aladino.PluginBuiltIns().Functions["formatDuration"].Code
+
+func TestFormatDuration(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	args := []aladino.Value{aladino.BuildDurationValue(2*24*60*60 + 60*60 + 30)}
+
+	gotVal, err := formatDuration(mockedEnv, args)
+
+	wantVal := aladino.BuildStringValue("2 days 1 hour 30 seconds")
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantVal, gotVal)
+}
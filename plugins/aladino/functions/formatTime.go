@@ -0,0 +1,26 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+// FormatTime formats a time in UTC with a layout in the format of the Go time package, e.g. "2006-01-02".
+func FormatTime() *aladino.BuiltInFunction {
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildTimeType(), aladino.BuildStringType()}, aladino.BuildStringType()),
+		Code:           formatTimeCode,
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+func formatTimeCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	t := args[0].(*aladino.TimeValue)
+	layout := args[1].(*aladino.StringValue).Val
+
+	return aladino.BuildStringValue(t.Time().Format(layout)), nil
+}
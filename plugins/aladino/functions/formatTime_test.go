@@ -0,0 +1,33 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var formatTime = plugins_aladino.PluginBuiltIns().Functions["formatTime"].Code
+
+func TestFormatTime(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	date := time.Date(2022, 4, 5, 22, 1, 50, 0, time.UTC)
+	args := []aladino.Value{
+		aladino.BuildTimeValue(int(date.Unix())),
+		aladino.BuildStringValue("Jan 2, 2006 at 15:04"),
+	}
+
+	gotVal, err := formatTime(mockedEnv, args)
+
+	wantVal := aladino.BuildStringValue("Apr 5, 2022 at 22:01")
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantVal, gotVal)
+}
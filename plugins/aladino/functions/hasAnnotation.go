@@ -23,6 +23,7 @@ func HasAnnotation() *aladino.BuiltInFunction {
 		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType()}, aladino.BuildBoolType()),
 		Code:           hasAnnotationCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+		Pure:           true,
 	}
 }
 
@@ -15,6 +15,7 @@ func HasLinkedIssues() *aladino.BuiltInFunction {
 		Type:           aladino.BuildFunctionType([]aladino.Type{}, aladino.BuildBoolType()),
 		Code:           hasLinkedIssuesCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+		Pure:           true,
 	}
 }
 
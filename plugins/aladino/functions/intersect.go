@@ -0,0 +1,44 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+// Intersect keeps the elements of the first array that are also in the second one.
+func Intersect() *aladino.BuiltInFunction {
+	elemType := aladino.BuildTypeVariable("A")
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildArrayOfType(elemType), aladino.BuildArrayOfType(elemType)}, aladino.BuildArrayOfType(elemType)),
+		Code:           intersectCode,
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+func intersectCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	elems := args[0].(*aladino.ArrayValue).Vals
+	otherElems := args[1].(*aladino.ArrayValue).Vals
+
+	result := make([]aladino.Value, 0)
+	for _, elem := range elems {
+		if isValueIn(elem, otherElems) {
+			result = append(result, elem)
+		}
+	}
+
+	return aladino.BuildArrayValue(result), nil
+}
+
+func isValueIn(value aladino.Value, values []aladino.Value) bool {
+	for _, otherValue := range values {
+		if value.Equals(otherValue) {
+			return true
+		}
+	}
+
+	return false
+}
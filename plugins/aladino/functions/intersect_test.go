@@ -0,0 +1,40 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var intersect = plugins_aladino.PluginBuiltIns().Functions["intersect"].Code
+
+func TestIntersect(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := intersect(mockedEnv, []aladino.Value{intValues(1, 2, 3, 4), intValues(4, 2, 5)})
+
+	assert.Nil(t, err)
+	assert.Equal(t, intValues(2, 4), gotVal)
+}
+
+func TestIntersect_WhenNoElementsInCommon(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := intersect(mockedEnv, []aladino.Value{intValues(1, 2), intValues(3)})
+
+	assert.Nil(t, err)
+	assert.Equal(t, intValues(), gotVal)
+}
+
+func TestIntersect_WhenArrayLiteralIsEmpty(t *testing.T) {
+	gotVal, err := evalSpec(t, `$length($intersect([], []))`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildIntValue(0), gotVal)
+}
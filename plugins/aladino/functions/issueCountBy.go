@@ -12,7 +12,7 @@ import (
 
 func IssueCountBy() *aladino.BuiltInFunction {
 	return &aladino.BuiltInFunction{
-		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType(), aladino.BuildStringType()}, aladino.BuildIntType()),
+		Type:           aladino.BuildFunctionTypeWithDefaults([]aladino.Type{aladino.BuildStringType(), aladino.BuildStringType()}, aladino.BuildIntType(), []aladino.Value{aladino.BuildStringValue("all")}),
 		Code:           issueCountByCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
 	}
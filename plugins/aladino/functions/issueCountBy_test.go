@@ -113,3 +113,36 @@ func TestIssueCountBy(t *testing.T) {
 		})
 	}
 }
+
+func TestIssueCountBy_WhenStateIsOmitted(t *testing.T) {
+	var gotState string
+	mockedEnv := aladino.MockDefaultEnv(
+		t,
+		[]mock.MockBackendOption{
+			mock.WithRequestMatchHandler(
+				mock.GetReposIssuesByOwnerByRepo,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotState = r.URL.Query().Get("state")
+					w.Write(mock.MustMarshal([]*github.Issue{}))
+				}),
+			),
+		},
+		nil,
+		plugins_aladino.PluginBuiltIns(),
+		nil,
+	)
+
+	expr, err := aladino.Parse(`$issueCountBy("steve")`)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	_, err = aladino.TypeInference(mockedEnv, expr)
+	assert.Nil(t, err)
+
+	gotVal, err := aladino.Eval(mockedEnv, expr)
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildIntValue(0), gotVal)
+	assert.Equal(t, "all", gotState)
+}
@@ -11,7 +11,7 @@ import (
 
 func LastEventAt() *aladino.BuiltInFunction {
 	return &aladino.BuiltInFunction{
-		Type:           aladino.BuildFunctionType([]aladino.Type{}, aladino.BuildIntType()),
+		Type:           aladino.BuildFunctionType([]aladino.Type{}, aladino.BuildTimeType()),
 		Code:           lastEventAtCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
 	}
@@ -33,5 +33,5 @@ func lastEventAtCode(e aladino.Env, args []aladino.Value) (aladino.Value, error)
 		lastEventTime = int(lastEvent.GetCreatedAt().Unix())
 	}
 
-	return aladino.BuildIntValue(lastEventTime), nil
+	return aladino.BuildTimeValue(lastEventTime), nil
 }
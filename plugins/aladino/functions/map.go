@@ -0,0 +1,46 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+func Map() *aladino.BuiltInFunction {
+	elemType := aladino.BuildTypeVariable("A")
+	resultType := aladino.BuildTypeVariable("B")
+	return &aladino.BuiltInFunction{
+		Type: aladino.BuildFunctionType(
+			[]aladino.Type{
+				aladino.BuildArrayOfType(elemType),
+				aladino.BuildFunctionType(
+					[]aladino.Type{elemType},
+					resultType,
+				),
+			},
+			aladino.BuildArrayOfType(resultType),
+		),
+		Code:           mapCode,
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+func mapCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	elems := args[0].(*aladino.ArrayValue).Vals
+	fn := args[1].(*aladino.FunctionValue).Fn
+
+	result := make([]aladino.Value, len(elems))
+	for i, elem := range elems {
+		value, err := fn([]aladino.Value{elem})
+		if err != nil {
+			return nil, err
+		}
+
+		result[i] = value
+	}
+
+	return aladino.BuildArrayValue(result), nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var mapFn = plugins_aladino.PluginBuiltIns().Functions["map"].Code
+
+func TestMap(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	args := []aladino.Value{
+		intValues(1, 2, 3),
+		aladino.BuildFunctionValue(func(args []aladino.Value) (aladino.Value, error) {
+			return aladino.BuildIntValue(args[0].(*aladino.IntValue).Val * 10), nil
+		}),
+	}
+	gotVal, err := mapFn(mockedEnv, args)
+
+	wantVal := intValues(10, 20, 30)
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestMap_WhenLambdaFails(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := mapFn(mockedEnv, []aladino.Value{intValues(1, 2, 3), failingFn})
+
+	assert.EqualError(t, err, "eval: division by zero")
+	assert.Nil(t, gotVal)
+}
+
+// failingFn is a function value whose body fails, as a lambda dividing by zero.
+var failingFn = aladino.BuildFunctionValue(func(args []aladino.Value) (aladino.Value, error) {
+	return nil, fmt.Errorf("eval: division by zero")
+})
+
+func intValues(vals ...int) *aladino.ArrayValue {
+	values := make([]aladino.Value, len(vals))
+	for i, val := range vals {
+		values[i] = aladino.BuildIntValue(val)
+	}
+
+	return aladino.BuildArrayValue(values)
+}
+
+// evalSpec infers the type of the spec and evaluates it with the built-ins of the plugin.
+func evalSpec(t *testing.T, spec string) (aladino.Value, error) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, plugins_aladino.PluginBuiltIns(), nil)
+
+	expr, err := aladino.Parse(spec)
+	if err != nil {
+		assert.FailNow(t, "parse failed", err)
+	}
+
+	if _, err := aladino.TypeInference(mockedEnv, expr); err != nil {
+		return nil, err
+	}
+
+	return aladino.Eval(mockedEnv, expr)
+}
+
+func TestMap_WhenArrayLiteralIsEmpty(t *testing.T) {
+	gotVal, err := evalSpec(t, `$map([], ($n: Int => $n * 2))`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, intValues(), gotVal)
+}
+
+func TestMap_WhenLambdaParameterCannotBeInferred(t *testing.T) {
+	gotVal, err := evalSpec(t, `$map([], ($n => $n * 2))`)
+
+	assert.EqualError(t, err, "type inference failed: cannot infer type of parameter n")
+	assert.Nil(t, gotVal)
+}
@@ -0,0 +1,34 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+func Matches() *aladino.BuiltInFunction {
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType(), aladino.BuildStringType()}, aladino.BuildBoolType()),
+		Code:           matchesCode,
+		RegexParams:    []int{1},
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+func matchesCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	str := args[0].(*aladino.StringValue).Val
+	pattern := args[1].(*aladino.StringValue).Val
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("matches: invalid regex %q: %v", pattern, err)
+	}
+
+	return aladino.BuildBoolValue(re.MatchString(str)), nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var matches = plugins_aladino.PluginBuiltIns().Functions["matches"].Code
+
+func TestMatches_WhenTrue(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	wantVal := aladino.BuildTrueValue()
+
+	args := []aladino.Value{
+		aladino.BuildStringValue("feat(lang): add regex builtins"),
+		aladino.BuildStringValue("^(feat|fix)\\(\\w+\\):"),
+	}
+
+	gotVal, err := matches(mockedEnv, args)
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestMatches_WhenFalse(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	wantVal := aladino.BuildFalseValue()
+
+	args := []aladino.Value{
+		aladino.BuildStringValue("docs: update readme"),
+		aladino.BuildStringValue("^(feat|fix)"),
+	}
+
+	gotVal, err := matches(mockedEnv, args)
+
+	assert.Nil(t, err)
+	assert.Equal(t, wantVal, gotVal)
+}
+
+func TestMatches_WhenInvalidRegex(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	args := []aladino.Value{
+		aladino.BuildStringValue("feat: add"),
+		aladino.BuildStringValue("^(feat"),
+	}
+
+	gotVal, err := matches(mockedEnv, args)
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "matches: invalid regex \"^(feat\": error parsing regexp: missing closing ): `^(feat`")
+}
+
+func TestMatches_WhenRegexLiteralIsInvalid(t *testing.T) {
+	err := lintRule(`$matches($title(), "[")`)
+
+	assert.EqualError(t, err, "[lint] rules[0].spec: invalid regex \"[\": error parsing regexp: missing closing ]: `[`\n --> line 1, column 20\n  |\n1 | $matches($title(), \"[\")\n  |                    ^^^")
+}
+
+// lintRule lints a reviewpad file with a single rule against the plugin built-ins.
+func lintRule(spec string) error {
+	file := &engine.ReviewpadFile{
+		Rules: []engine.PadRule{{Name: "rule", Spec: spec}},
+	}
+
+	return aladino.Lint(file, plugins_aladino.PluginBuiltIns())
+}
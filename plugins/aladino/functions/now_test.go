@@ -0,0 +1,28 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = plugins_aladino.PluginBuiltIns().Functions["now"].Code
+
+func TestNow(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	before := int(time.Now().Unix())
+	gotVal, err := now(mockedEnv, []aladino.Value{})
+	after := int(time.Now().Unix())
+
+	assert.Nil(t, err)
+	assert.GreaterOrEqual(t, gotVal.(*aladino.TimeValue).Val, before)
+	assert.LessOrEqual(t, gotVal.(*aladino.TimeValue).Val, after)
+}
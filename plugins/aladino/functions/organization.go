@@ -14,6 +14,7 @@ func Organization() *aladino.BuiltInFunction {
 		Type:           aladino.BuildFunctionType([]aladino.Type{}, aladino.BuildArrayOfType(aladino.BuildStringType())),
 		Code:           organizationCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+		Pure:           true,
 	}
 }
 
@@ -0,0 +1,37 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+func Replace() *aladino.BuiltInFunction {
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType(), aladino.BuildStringType(), aladino.BuildStringType()}, aladino.BuildStringType()),
+		Code:           replaceCode,
+		RegexParams:    []int{1},
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+// replaceCode replaces every match of the regex with the replacement.
+// Inside the replacement, $1 refers to the first capturing group and so on.
+func replaceCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	str := args[0].(*aladino.StringValue).Val
+	pattern := args[1].(*aladino.StringValue).Val
+	repl := args[2].(*aladino.StringValue).Val
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("replace: invalid regex %q: %v", pattern, err)
+	}
+
+	return aladino.BuildStringValue(re.ReplaceAllString(str, repl)), nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var replace = plugins_aladino.PluginBuiltIns().Functions["replace"].Code
+
+func TestReplace(t *testing.T) {
+	tests := map[string]struct {
+		args    []aladino.Value
+		wantVal aladino.Value
+	}{
+		"replaces every match": {
+			args:    []aladino.Value{aladino.BuildStringValue("a-b-c"), aladino.BuildStringValue("-"), aladino.BuildStringValue("_")},
+			wantVal: aladino.BuildStringValue("a_b_c"),
+		},
+		"replacement with capturing group": {
+			args:    []aladino.Value{aladino.BuildStringValue("feature/login"), aladino.BuildStringValue("^feature/(.*)$"), aladino.BuildStringValue("feat: $1")},
+			wantVal: aladino.BuildStringValue("feat: login"),
+		},
+		"no match": {
+			args:    []aladino.Value{aladino.BuildStringValue("main"), aladino.BuildStringValue("^feature/"), aladino.BuildStringValue("")},
+			wantVal: aladino.BuildStringValue("main"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+			gotVal, err := replace(mockedEnv, test.args)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.wantVal, gotVal)
+		})
+	}
+}
+
+func TestReplace_WhenInvalidRegex(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	args := []aladino.Value{
+		aladino.BuildStringValue("feat: add"),
+		aladino.BuildStringValue("*feat"),
+		aladino.BuildStringValue(""),
+	}
+
+	gotVal, err := replace(mockedEnv, args)
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "replace: invalid regex \"*feat\": error parsing regexp: missing argument to repetition operator: `*`")
+}
+
+func TestReplace_WhenRegexLiteralIsInvalid(t *testing.T) {
+	err := lintRule(`$replace($title(), "*feat", "") != ""`)
+
+	assert.ErrorContains(t, err, "[lint] rules[0].spec: invalid regex \"*feat\"")
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"github.com/reviewpad/reviewpad/v3/codehost/github/target"
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+func Reviews() *aladino.BuiltInFunction {
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{}, aladino.BuildArrayOfType(reviewType())),
+		Code:           reviewsCode,
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest},
+	}
+}
+
+func reviewsCode(e aladino.Env, _ []aladino.Value) (aladino.Value, error) {
+	t := e.GetTarget().(*target.PullRequestTarget)
+	ghReviews, err := t.GetReviews()
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]aladino.Value, 0, len(ghReviews))
+	for _, ghReview := range ghReviews {
+		// The pending reviews are not submitted yet, so they have no submission time.
+		if ghReview.SubmittedAt.IsZero() {
+			continue
+		}
+
+		// The user of a review is missing when its account was deleted.
+		user := ""
+		if ghReview.User != nil {
+			user = ghReview.User.Login
+		}
+
+		reviews = append(reviews, aladino.BuildRecordValue(map[string]aladino.Value{
+			"user":        aladino.BuildStringValue(user),
+			"state":       aladino.BuildStringValue(ghReview.State),
+			"body":        aladino.BuildStringValue(ghReview.Body),
+			"submittedAt": aladino.BuildTimeValue(int(ghReview.SubmittedAt.Unix())),
+		}))
+	}
+
+	return aladino.BuildArrayValue(reviews), nil
+}
+
+func reviewType() *aladino.RecordType {
+	return aladino.BuildRecordType(map[string]aladino.Type{
+		"user":        aladino.BuildStringType(),
+		"state":       aladino.BuildStringType(),
+		"body":        aladino.BuildStringType(),
+		"submittedAt": aladino.BuildTimeType(),
+	})
+}
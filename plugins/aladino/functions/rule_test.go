@@ -37,7 +37,11 @@ func TestRule_WhenRuleIsInvalid(t *testing.T) {
 	gotVal, err := rule(mockedEnv, args)
 
 	assert.Nil(t, gotVal)
-	assert.EqualError(t, err, "type inference failed")
+	assert.EqualError(t, err, `type inference failed
+ --> line 1, column 1
+  |
+1 | 1 == "a"
+  | ^^^^^^^^`)
 }
 
 func TestRule_WhenRuleIsTrue(t *testing.T) {
@@ -0,0 +1,69 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+// Sort sorts an array of integers, strings, times or durations in ascending order.
+func Sort() *aladino.BuiltInFunction {
+	// The elements are restricted to the types ordered by lessValue.
+	elemType := aladino.BuildRestrictedTypeVariable("A", []string{aladino.INT_TYPE, aladino.STRING_TYPE, aladino.TIME_TYPE, aladino.DURATION_TYPE})
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildArrayOfType(elemType)}, aladino.BuildArrayOfType(elemType)),
+		Code:           sortCode,
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+func sortCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	elems := args[0].(*aladino.ArrayValue).Vals
+
+	result := make([]aladino.Value, len(elems))
+	copy(result, elems)
+
+	var err error
+	sort.SliceStable(result, func(i, j int) bool {
+		isLess, lessErr := lessValue(result[i], result[j])
+		if lessErr != nil {
+			err = lessErr
+		}
+		return isLess
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return aladino.BuildArrayValue(result), nil
+}
+
+func lessValue(value aladino.Value, other aladino.Value) (bool, error) {
+	switch val := value.(type) {
+	case *aladino.IntValue:
+		if otherVal, ok := other.(*aladino.IntValue); ok {
+			return val.Val < otherVal.Val, nil
+		}
+	case *aladino.StringValue:
+		if otherVal, ok := other.(*aladino.StringValue); ok {
+			return val.Val < otherVal.Val, nil
+		}
+	case *aladino.TimeValue:
+		if otherVal, ok := other.(*aladino.TimeValue); ok {
+			return val.Val < otherVal.Val, nil
+		}
+	case *aladino.DurationValue:
+		if otherVal, ok := other.(*aladino.DurationValue); ok {
+			return val.Val < otherVal.Val, nil
+		}
+	}
+
+	return false, fmt.Errorf("sort: cannot order %v and %v values", value.Kind(), other.Kind())
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions_test
+
+import (
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+	plugins_aladino "github.com/reviewpad/reviewpad/v3/plugins/aladino"
+	"github.com/stretchr/testify/assert"
+)
+
+var sortFn = plugins_aladino.PluginBuiltIns().Functions["sort"].Code
+
+func TestSort_WhenInts(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := sortFn(mockedEnv, []aladino.Value{intValues(3, 1, 2)})
+
+	assert.Nil(t, err)
+	assert.Equal(t, intValues(1, 2, 3), gotVal)
+}
+
+func TestSort_WhenStrings(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := sortFn(mockedEnv, []aladino.Value{aladino.BuildArrayValue([]aladino.Value{aladino.BuildStringValue("b"), aladino.BuildStringValue("a")})})
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildArrayValue([]aladino.Value{aladino.BuildStringValue("a"), aladino.BuildStringValue("b")}), gotVal)
+}
+
+func TestSort_WhenTimes(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	gotVal, err := sortFn(mockedEnv, []aladino.Value{aladino.BuildArrayValue([]aladino.Value{aladino.BuildTimeValue(20), aladino.BuildTimeValue(10)})})
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildArrayValue([]aladino.Value{aladino.BuildTimeValue(10), aladino.BuildTimeValue(20)}), gotVal)
+}
+
+func TestSort_WhenElementsCannotBeOrdered(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, aladino.MockBuiltIns(), nil)
+
+	args := []aladino.Value{
+		aladino.BuildArrayValue([]aladino.Value{aladino.BuildBoolValue(true), aladino.BuildBoolValue(false)}),
+	}
+	gotVal, err := sortFn(mockedEnv, args)
+
+	assert.Nil(t, gotVal)
+	assert.EqualError(t, err, "sort: cannot order BoolValue and BoolValue values")
+}
+
+func TestSort_WhenElementTypeIsNotOrdered(t *testing.T) {
+	mockedEnv := aladino.MockDefaultEnv(t, nil, nil, plugins_aladino.PluginBuiltIns(), nil)
+
+	tests := map[string]struct {
+		spec    string
+		wantErr bool
+	}{
+		"ints":      {spec: `$sort([3, 1, 2])`},
+		"durations": {spec: `$sort([2 days, 1 day])`},
+		"empty":     {spec: `$sort([])`},
+		"bools":     {spec: `$sort([true, false])`, wantErr: true},
+		"arrays":    {spec: `$sort([[1], [2]])`, wantErr: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := aladino.Parse(test.spec)
+			if err != nil {
+				assert.FailNow(t, "parse failed", err)
+			}
+
+			_, err = aladino.TypeInference(mockedEnv, expr)
+
+			if test.wantErr {
+				assert.ErrorContains(t, err, "type inference failed: mismatch in arg types on sort")
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestSort_WhenArrayLiteralIsEmpty(t *testing.T) {
+	gotVal, err := evalSpec(t, `$length($sort([]))`)
+
+	assert.Nil(t, err)
+	assert.Equal(t, aladino.BuildIntValue(0), gotVal)
+}
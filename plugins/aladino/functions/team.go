@@ -15,6 +15,7 @@ func Team() *aladino.BuiltInFunction {
 		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType()}, aladino.BuildArrayOfType(aladino.BuildStringType())),
 		Code:           teamCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+		Pure:           true,
 	}
 }
 
@@ -15,6 +15,7 @@ func TotalCreatedPullRequests() *aladino.BuiltInFunction {
 		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildStringType()}, aladino.BuildIntType()),
 		Code:           totalCreatedPullRequestsCode,
 		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+		Pure:           true,
 	}
 }
 
@@ -0,0 +1,33 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package plugins_aladino_functions
+
+import (
+	"github.com/reviewpad/reviewpad/v3/handler"
+	"github.com/reviewpad/reviewpad/v3/lang/aladino"
+)
+
+// Unique removes the repeated elements of an array, keeping the first occurrence of each one.
+func Unique() *aladino.BuiltInFunction {
+	elemType := aladino.BuildTypeVariable("A")
+	return &aladino.BuiltInFunction{
+		Type:           aladino.BuildFunctionType([]aladino.Type{aladino.BuildArrayOfType(elemType)}, aladino.BuildArrayOfType(elemType)),
+		Code:           uniqueCode,
+		SupportedKinds: []handler.TargetEntityKind{handler.PullRequest, handler.Issue},
+	}
+}
+
+func uniqueCode(e aladino.Env, args []aladino.Value) (aladino.Value, error) {
+	elems := args[0].(*aladino.ArrayValue).Vals
+
+	result := make([]aladino.Value, 0)
+	for _, elem := range elems {
+		if !isValueIn(elem, result) {
+			result = append(result, elem)
+		}
+	}
+
+	return aladino.BuildArrayValue(result), nil
+}
@@ -5,10 +5,13 @@
 package reviewpad
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"strings"
 
 	gh "github.com/reviewpad/reviewpad/v3/codehost/github"
 	"github.com/reviewpad/reviewpad/v3/collector"
@@ -20,7 +23,13 @@ import (
 )
 
 func Load(buf *bytes.Buffer) (*engine.ReviewpadFile, error) {
-	file, err := engine.Load(buf.Bytes())
+	return LoadFile(context.Background(), nil, "", buf)
+}
+
+// LoadFile loads the reviewpad file at filePath, whose local imports are relative to filePath,
+// fetching the files imported from repositories with the GitHub client.
+func LoadFile(ctx context.Context, githubClient *gh.GithubClient, filePath string, buf *bytes.Buffer) (*engine.ReviewpadFile, error) {
+	file, err := engine.LoadFile(ctx, githubClient, filePath, buf.Bytes())
 	if err != nil {
 		return nil, err
 	}
@@ -32,6 +41,12 @@ func Load(buf *bytes.Buffer) (*engine.ReviewpadFile, error) {
 		return nil, err
 	}
 
+	// The types of the built-ins do not depend on the services of the plugins.
+	err = aladino.Lint(file, plugins_aladino.PluginBuiltInsWithConfig(&plugins_aladino.PluginConfig{}))
+	if err != nil {
+		return nil, err
+	}
+
 	return file, nil
 }
 
@@ -95,3 +110,66 @@ func Run(
 
 	return exitStatus, nil
 }
+
+// Repl reads Aladino expressions from in, one per line, and writes their values and types to out.
+// The expressions are evaluated on the target entity with the built-ins and the functions, groups, labels and rules
+// of the reviewpad file, whose workflows and pipelines are not run. Without a reviewpad file, only the built-ins are available.
+// Actions are only executed when allowActions is set, otherwise the REPL runs in dry run.
+func Repl(
+	ctx context.Context,
+	githubClient *gh.GithubClient,
+	collector collector.Collector,
+	targetEntity *handler.TargetEntity,
+	eventPayload interface{},
+	reviewpadFile *engine.ReviewpadFile,
+	allowActions bool,
+	in io.Reader,
+	out io.Writer,
+) error {
+	config, err := plugins_aladino.DefaultPluginConfig()
+	if err != nil {
+		return err
+	}
+
+	defer config.CleanupPluginConfig()
+
+	dryRun := !allowActions
+
+	aladinoInterpreter, err := aladino.NewInterpreter(ctx, dryRun, githubClient, collector, targetEntity, eventPayload, plugins_aladino.PluginBuiltInsWithConfig(config))
+	if err != nil {
+		return err
+	}
+
+	evalEnv, err := engine.NewEvalEnv(ctx, dryRun, githubClient, collector, targetEntity, aladinoInterpreter)
+	if err != nil {
+		return err
+	}
+
+	if reviewpadFile != nil {
+		err = engine.Register(reviewpadFile, evalEnv)
+		if err != nil {
+			return err
+		}
+	}
+
+	interpreter := aladinoInterpreter.(*aladino.Interpreter)
+
+	scanner := bufio.NewScanner(in)
+	fmt.Fprint(out, "> ")
+	for scanner.Scan() {
+		input := strings.TrimSpace(scanner.Text())
+		if input != "" {
+			result, err := interpreter.Inspect(input)
+			if err != nil {
+				fmt.Fprintf(out, "error: %v\n", err)
+			} else {
+				fmt.Fprintln(out, result)
+			}
+		}
+
+		fmt.Fprint(out, "> ")
+	}
+	fmt.Fprintln(out)
+
+	return scanner.Err()
+}
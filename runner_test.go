@@ -0,0 +1,35 @@
+// Copyright 2022 Explore.dev Unipessoal Lda. All Rights Reserved.
+// Use of this source code is governed by a license that can be
+// found in the LICENSE file.
+
+package reviewpad_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/reviewpad/reviewpad/v3"
+	"github.com/reviewpad/reviewpad/v3/engine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepl_WhenThereIsNoReviewpadFile(t *testing.T) {
+	in := strings.NewReader("$title()\n")
+	var out bytes.Buffer
+
+	err := reviewpad.Repl(
+		engine.DefaultMockCtx,
+		engine.MockGithubClient(nil),
+		engine.DefaultMockCollector,
+		engine.DefaultMockTargetEntity,
+		engine.DefaultMockEventPayload,
+		nil,
+		false,
+		in,
+		&out,
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "> \"Amazing new feature\" : String\n> \n", out.String())
+}